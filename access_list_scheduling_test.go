@@ -0,0 +1,42 @@
+package scheduler
+
+import "testing"
+
+func TestNewSeparatesMessagesWithOverlappingAccessLists(t *testing.T) {
+	s := NewScheduler()
+	// No learned conflict between these callees at all — the access list
+	// alone must be enough to force separation.
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"p"}},
+		{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"p"}},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected overlapping access lists to force separate generations, got %v", sched.Generations)
+	}
+}
+
+func TestNewOverrulesLearnedConflictWhenAccessListsDontOverlap(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"p1"}},
+		{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"p2"}},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected declared disjoint access lists to overrule the coarse callee conflict, got %v", sched.Generations)
+	}
+}
+
+func TestNewFallsBackToCalleeHistoryWithoutAccessLists(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the learned conflict to still apply without access lists, got %v", sched.Generations)
+	}
+}
@@ -0,0 +1,23 @@
+// Package address canonicalizes the address strings the scheduler keys
+// its learned callee statistics by, so runtimes with address formats
+// other than lowercase EVM hex don't fragment those statistics across
+// surface-level variants of the same address.
+package address
+
+import "strings"
+
+// Normalizer canonicalizes an address string so equivalent addresses
+// compare equal regardless of surface formatting (case, padding, prefix).
+type Normalizer func(addr string) string
+
+// EVM lowercases a 0x-prefixed hex address, the canonical form used by
+// EVM-compatible runtimes. It is the default normalizer.
+func EVM(addr string) string {
+	return strings.ToLower(addr)
+}
+
+// Identity returns addr unchanged, for runtimes whose native address
+// format is already canonical.
+func Identity(addr string) string {
+	return addr
+}
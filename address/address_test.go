@@ -0,0 +1,15 @@
+package address
+
+import "testing"
+
+func TestEVMLowercases(t *testing.T) {
+	if got := EVM("0xABC"); got != "0xabc" {
+		t.Fatalf("expected lowercase hex, got %q", got)
+	}
+}
+
+func TestIdentityIsUnchanged(t *testing.T) {
+	if got := Identity("Contract#1"); got != "Contract#1" {
+		t.Fatalf("expected unchanged address, got %q", got)
+	}
+}
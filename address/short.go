@@ -0,0 +1,59 @@
+package address
+
+import "sync"
+
+// ShortNormalizer canonicalizes addresses down to a configurable-length
+// prefix, for runtimes that key their own state by a truncated address
+// rather than the full form. Truncating on its own risks two distinct
+// full addresses sharing a short prefix and silently colliding in
+// whatever the caller keys by that prefix (the scheduler's calleeDict,
+// for one). ShortNormalizer guards against that: the first full address
+// to claim a given short prefix keeps using it, and any other full
+// address that would collide with an already-claimed prefix falls back
+// to Normalize returning its full, unshortened form instead, so it never
+// gets attributed the claimant's learned statistics.
+type ShortNormalizer struct {
+	length int
+
+	mu         sync.Mutex
+	claimants  map[string]string // short prefix -> the full address that claimed it
+	collisions int
+}
+
+// NewShortNormalizer returns a ShortNormalizer that truncates normalized
+// addresses to length characters. A non-positive length disables
+// truncation entirely; Normalize then behaves like EVM.
+func NewShortNormalizer(length int) *ShortNormalizer {
+	return &ShortNormalizer{length: length, claimants: make(map[string]string)}
+}
+
+// Normalize implements the address.Normalizer signature.
+func (n *ShortNormalizer) Normalize(addr string) string {
+	full := EVM(addr)
+	if n.length <= 0 || len(full) <= n.length {
+		return full
+	}
+	short := full[:n.length]
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	claimant, ok := n.claimants[short]
+	if !ok {
+		n.claimants[short] = full
+		return short
+	}
+	if claimant == full {
+		return short
+	}
+	n.collisions++
+	return full
+}
+
+// Collisions reports how many Normalize calls have fallen back to a full
+// address because their short prefix was already claimed by a different
+// address.
+func (n *ShortNormalizer) Collisions() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.collisions
+}
@@ -0,0 +1,48 @@
+package address
+
+import "testing"
+
+func TestShortNormalizerTruncatesToLength(t *testing.T) {
+	n := NewShortNormalizer(6)
+	if got := n.Normalize("0xABCDEF1234"); got != "0xabcd" {
+		t.Fatalf("expected a 6-char truncated prefix, got %q", got)
+	}
+}
+
+func TestShortNormalizerFallsBackToFullAddressOnCollision(t *testing.T) {
+	n := NewShortNormalizer(6)
+
+	a := n.Normalize("0xabcd111111")
+	b := n.Normalize("0xabcd222222")
+
+	if a != "0xabcd" {
+		t.Fatalf("expected the first claimant to keep the short prefix, got %q", a)
+	}
+	if b != "0xabcd222222" {
+		t.Fatalf("expected the colliding address to fall back to its full form, got %q", b)
+	}
+	if n.Collisions() != 1 {
+		t.Fatalf("expected 1 recorded collision, got %d", n.Collisions())
+	}
+}
+
+func TestShortNormalizerIsStableForTheSameAddress(t *testing.T) {
+	n := NewShortNormalizer(6)
+
+	first := n.Normalize("0xabcd111111")
+	second := n.Normalize("0xABCD111111")
+
+	if first != second {
+		t.Fatalf("expected repeated normalization of the same address to be stable, got %q and %q", first, second)
+	}
+	if n.Collisions() != 0 {
+		t.Fatalf("expected no collision for the same address seen twice, got %d", n.Collisions())
+	}
+}
+
+func TestShortNormalizerNonPositiveLengthDisablesTruncation(t *testing.T) {
+	n := NewShortNormalizer(0)
+	if got := n.Normalize("0xABCDEF"); got != "0xabcdef" {
+		t.Fatalf("expected full normalized address with truncation disabled, got %q", got)
+	}
+}
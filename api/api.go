@@ -0,0 +1,24 @@
+// Package api is the minimal, stable public surface of the scheduler
+// module: the handful of types and constructors integrators are expected
+// to depend on directly. Everything else in this module is free to
+// change shape between releases; this package only changes deliberately.
+package api
+
+import "github.com/arcology-network/scheduler"
+
+type (
+	// Scheduler builds execution schedules for a batch of messages.
+	Scheduler = scheduler.Scheduler
+	// Message is a single transaction submitted for scheduling.
+	Message = scheduler.Message
+	// Schedule is the output of a scheduling pass.
+	Schedule = scheduler.Schedule
+	// Callee tracks scheduling-relevant statistics for a distinct
+	// (address, signature) pair.
+	Callee = scheduler.Callee
+)
+
+// New returns an empty Scheduler with no learned conflicts.
+func New() *Scheduler {
+	return scheduler.NewScheduler()
+}
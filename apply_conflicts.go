@@ -0,0 +1,45 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/arbitrator"
+
+// TxCallee names the (address, function signature) pair a transaction ID
+// invokes. ApplyConflicts needs this mapping to translate arbitrator
+// output — which is keyed by transaction ID — into the callee-keyed
+// conflicts AddWithEvidence expects.
+type TxCallee struct {
+	To  string
+	Sig string
+}
+
+// ApplyConflicts takes the Conflicts an arbitrator.Detect pass reported
+// plus a txID->TxCallee mapping, and records the corresponding
+// AddWithEvidence call for each one, so every integrator stops writing
+// this glue code by hand. Deduplication (the same callee pair recorded
+// more than once) and threshold logic (see SetConflictConfidence) need no
+// special handling here — they're exactly the addLocked bookkeeping
+// AddWithEvidence already goes through. A conflict whose A or B
+// transaction ID has no entry in callees is skipped, since there's no
+// callee to record it against. It locks once for the whole batch rather
+// than once per conflict, and returns how many conflicts were actually
+// applied.
+func (s *Scheduler) ApplyConflicts(conflicts []arbitrator.Conflict, callees map[uint64]TxCallee) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied := 0
+	for _, c := range conflicts {
+		a, ok := callees[c.A]
+		if !ok {
+			continue
+		}
+		b, ok := callees[c.B]
+		if !ok {
+			continue
+		}
+		keyA := calleeKey(s.normalize(a.To), a.Sig)
+		keyB := calleeKey(s.normalize(b.To), b.Sig)
+		s.addWithEvidenceLocked(keyA, keyB, c)
+		applied++
+	}
+	return applied
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+func TestApplyConflictsRecordsAConflictForEachResolvedPair(t *testing.T) {
+	s := NewScheduler()
+	callees := map[uint64]TxCallee{
+		1: {To: "0xA", Sig: "f()"},
+		2: {To: "0xB", Sig: "g()"},
+	}
+	conflicts := []arbitrator.Conflict{
+		{A: 1, B: 2, Path: "shared"},
+	}
+
+	applied := s.ApplyConflicts(conflicts, callees)
+	if applied != 1 {
+		t.Fatalf("expected 1 conflict applied, got %d", applied)
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the resolved callee pair to be recorded as conflicting")
+	}
+	if _, ok := s.Evidence(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")); !ok {
+		t.Fatal("expected the conflict to be recorded as evidence")
+	}
+}
+
+func TestApplyConflictsSkipsUnresolvableTransactionIDs(t *testing.T) {
+	s := NewScheduler()
+	callees := map[uint64]TxCallee{
+		1: {To: "0xA", Sig: "f()"},
+	}
+	conflicts := []arbitrator.Conflict{
+		{A: 1, B: 99, Path: "shared"},
+	}
+
+	applied := s.ApplyConflicts(conflicts, callees)
+	if applied != 0 {
+		t.Fatalf("expected 0 conflicts applied when a resolution is missing, got %d", applied)
+	}
+}
+
+func TestApplyConflictsRespectsConflictConfidenceThreshold(t *testing.T) {
+	s := NewScheduler()
+	s.SetConflictConfidence(2)
+	callees := map[uint64]TxCallee{
+		1: {To: "0xA", Sig: "f()"},
+		2: {To: "0xB", Sig: "g()"},
+	}
+	conflicts := []arbitrator.Conflict{{A: 1, B: 2, Path: "shared"}}
+
+	s.ApplyConflicts(conflicts, callees)
+	if s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected a single observation to fall short of the confidence threshold")
+	}
+	s.ApplyConflicts(conflicts, callees)
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected two observations to meet the confidence threshold")
+	}
+}
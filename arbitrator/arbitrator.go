@@ -0,0 +1,553 @@
+// Package arbitrator detects read/write conflicts across the state paths
+// touched by a set of messages executed within the same block.
+package arbitrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arcology-network/scheduler/metrics"
+	"github.com/arcology-network/scheduler/wildcard"
+)
+
+// ErrMemoryLimitExceeded is returned by Detect once the arbitrator's dict
+// has grown past its configured memory limit, so a malicious block with
+// millions of tiny unique paths can be rejected instead of OOMing the
+// validator.
+var ErrMemoryLimitExceeded = errors.New("arbitrator: memory limit exceeded")
+
+// bytesPerEntry approximates the fixed overhead of one dict entry: the map
+// bucket, the entry struct, and its writer/hasW fields.
+const bytesPerEntry = 48
+
+// Reason classifies why two accesses were flagged as conflicting.
+type Reason int
+
+const (
+	// ReasonWriteWrite means both messages wrote the same path.
+	ReasonWriteWrite Reason = iota
+	// ReasonReadWrite means one message read a path the other wrote.
+	ReasonReadWrite
+	// ReasonWildcard means the conflict was introduced by expanding a
+	// wildcard write (e.g. a container clear-all) against a concrete
+	// element path, rather than by two literal path accesses.
+	ReasonWildcard
+	// ReasonBoundsExceeded means a commutative write's delta, applied to
+	// its path's running total, was rejected by a BoundsChecker
+	// registered via SetBoundsChecker.
+	ReasonBoundsExceeded
+)
+
+// Access is a single message's read/write footprint, as seen by the
+// arbitrator. IDs must be unique within one Detect call. WildcardWrites
+// holds clear-all style patterns (e.g. "container/*") that are expanded
+// against every path the arbitrator has seen so far.
+type Access struct {
+	ID       uint64
+	ReadSet  []string
+	WriteSet []string
+	// WildcardWrites holds clear-all style patterns (e.g. "container/*")
+	// expanded against every path the arbitrator has seen so far.
+	WildcardWrites []string
+	// CommutativeWrites holds paths written in a way that is safe to run
+	// concurrently with other commutative writes to the same path — the
+	// canonical example being a coinbase/fee-recipient balance credit,
+	// where every message just adds to the total and order doesn't
+	// matter. They still conflict with a plain read or write of the same
+	// path.
+	CommutativeWrites []string
+	// CommutativeDeltas gives the numeric delta a CommutativeWrites (or a
+	// conflict-free, see MarkConflictFree) path is applying, for a
+	// BoundsChecker registered via SetBoundsChecker to validate. A path
+	// with no entry here defaults to a delta of 0, a safe no-op for
+	// callers that never register a BoundsChecker.
+	CommutativeDeltas map[string]int64
+	// GroupID tags which caller-defined group (e.g. a scheduler
+	// generation) this Access's transaction belongs to, for GroupConflicts
+	// to aggregate conflicts by. It plays no role in detection itself and
+	// defaults to 0, a valid group like any other.
+	GroupID uint64
+}
+
+// Conflict records that message A and message B accessed the same path in
+// a way that is not safe to run concurrently. WildcardPath and WildcardTx
+// are set only when Reason is ReasonWildcard: WildcardPath carries the
+// original clear-all pattern that expanded to Path, and WildcardTx names
+// the message that issued it, so the "delete everything" transaction that
+// caused the conflict can be identified and penalized or deferred next
+// block without callers having to infer it from A/B ordering.
+type Conflict struct {
+	A, B         uint64
+	Path         string
+	Reason       Reason
+	WildcardPath string
+	WildcardTx   uint64
+}
+
+type entry struct {
+	writer      uint64
+	hasW        bool
+	commutative bool
+	readers     []uint64
+	// total is the running sum of every commutative delta applied to
+	// this path so far this block, checked against a registered
+	// BoundsChecker (see SetBoundsChecker) before being updated.
+	total int64
+}
+
+// Arbitrator accumulates accesses path-by-path and reports the conflicts
+// they imply. Detect is incremental by design: each call only examines
+// the Accesses it is given, not a full rescan of everything seen before,
+// so an executor can stream a block through it generation by generation
+// — inserting one generation's transitions and getting back only the
+// conflicts that generation introduces — while later generations are
+// still executing.
+//
+// The path dict is sharded (see shard.go) rather than guarded by one
+// global lock: a path always hashes to the same shard, and two accesses
+// can only conflict if they touch the same path, so accesses to
+// different paths never need to coordinate with each other. That lets
+// separate Detect calls against the same Arbitrator — from separate
+// goroutines arbitrating separate generations, for example — actually
+// run concurrently instead of serializing on a single mutex, which
+// matters once a block's total footprint spans millions of paths. Only
+// the rarely-touched configuration (observer, MarkConflictFree hints,
+// bounds checkers, and the wildcard-prefix bucket cache) still goes
+// through a single mu, since those are read on every access but almost
+// never written; the memory limit and usage counter are atomics.
+type Arbitrator struct {
+	mu             sync.RWMutex
+	shards         []*dictShard
+	memoryLimit    atomic.Uint64
+	memBytes       atomic.Uint64
+	conflictFree   []string
+	hasHints       atomic.Bool
+	prefixBuckets  map[string]map[string]struct{}
+	observer       metrics.Observer
+	boundsHints    []boundsHint
+	hasBoundsHints atomic.Bool
+}
+
+// SetObserver installs an Observer that Detect reports the number of
+// conflicts it finds and how long it took to. A nil observer (the
+// default) disables reporting entirely.
+func (a *Arbitrator) SetObserver(o metrics.Observer) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.observer = o
+}
+
+func (a *Arbitrator) obs() metrics.Observer {
+	a.mu.RLock()
+	o := a.observer
+	a.mu.RUnlock()
+	if o == nil {
+		return metrics.Noop{}
+	}
+	return o
+}
+
+// New returns an empty Arbitrator with no memory limit.
+func New() *Arbitrator {
+	return &Arbitrator{shards: newShards(), prefixBuckets: make(map[string]map[string]struct{})}
+}
+
+// MarkConflictFree registers a path, or a "prefix*" wildcard, as backed by
+// a concurrent-safe container from the Arcology concurrent library (e.g. a
+// cumulative u256 map). Plain writes to a matching path are then treated
+// as commutative automatically, without every caller having to route them
+// through Access.CommutativeWrites itself.
+func (a *Arbitrator) MarkConflictFree(pathOrPrefix string) {
+	a.mu.Lock()
+	a.conflictFree = append(a.conflictFree, pathOrPrefix)
+	a.mu.Unlock()
+	a.hasHints.Store(true)
+}
+
+func (a *Arbitrator) isConflictFree(path string) bool {
+	if !a.hasHints.Load() {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, hint := range a.conflictFree {
+		if hint == path {
+			return true
+		}
+		if p := wildcard.Compile(hint); p.IsWildcard() && p.Covers(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMemoryLimit caps MemoryUsage. Once the dict grows past limit, Detect
+// stops accepting new accesses and returns ErrMemoryLimitExceeded. A limit
+// of 0 disables the cap.
+func (a *Arbitrator) SetMemoryLimit(limit uint64) {
+	a.memoryLimit.Store(limit)
+}
+
+// MemoryUsage returns an approximate byte count for everything currently
+// held in the arbitrator's dict: one bytesPerEntry per distinct path, plus
+// the path string itself and 8 bytes per recorded reader. It is tracked
+// incrementally as recordWrite/recordRead/etc. touch the dict rather than
+// recomputed by scanning every shard, so calling it — including the check
+// Detect makes against SetMemoryLimit on every access — costs one atomic
+// load regardless of how many paths the arbitrator has accumulated.
+func (a *Arbitrator) MemoryUsage() uint64 {
+	return a.memoryUsage()
+}
+
+func (a *Arbitrator) memoryUsage() uint64 {
+	return a.memBytes.Load()
+}
+
+// trackNewEntry accounts for path's fixed per-entry overhead the first
+// time it's seen within a block; recordRead separately accounts for each
+// reader it appends.
+func (a *Arbitrator) trackNewEntry(path string) {
+	a.memBytes.Add(uint64(len(path)) + bytesPerEntry)
+}
+
+// Detect records accs against the arbitrator's dict and returns every
+// conflict they introduce. If a memory limit is set and would be exceeded,
+// it stops early and returns ErrMemoryLimitExceeded alongside whatever
+// conflicts were found before the limit was hit.
+func (a *Arbitrator) Detect(accs []Access) ([]Conflict, error) {
+	return a.detect(context.Background(), accs, nil)
+}
+
+// DetectWithContext behaves like Detect, but checks ctx between accesses
+// and returns early with ctx.Err() once the context is canceled or its
+// deadline expires, alongside whatever conflicts were found before that
+// — so a validator with a block-building deadline doesn't block past it
+// arbitrating a large batch.
+func (a *Arbitrator) DetectWithContext(ctx context.Context, accs []Access) ([]Conflict, error) {
+	return a.detect(ctx, accs, nil)
+}
+
+// DetectInto behaves like DetectWithContext, but appends conflicts onto
+// dst instead of a freshly allocated slice, so a caller processing many
+// blocks in sequence can pass in the previous block's slice (truncated to
+// length 0) and reuse its backing array instead of allocating a new one
+// every block.
+func (a *Arbitrator) DetectInto(ctx context.Context, accs []Access, dst []Conflict) ([]Conflict, error) {
+	return a.detect(ctx, accs, dst)
+}
+
+// DetectFor behaves like Detect, but only records and arbitrates the
+// Accesses in accs whose ID appears in txIDs, ignoring the rest. It
+// exists for cheap re-arbitration after a rollback re-executed only a
+// handful of transactions out of a much larger block: rebuilding accs
+// for the whole block just to re-detect a few of them would waste most
+// of the work, while DetectFor arbitrates just the re-executed
+// transactions against the dict's existing state (everything else
+// already recorded by an earlier Detect call). accs itself still needs
+// every re-executed transaction's Access; txIDs only says which of them
+// to actually process this call.
+func (a *Arbitrator) DetectFor(accs []Access, txIDs []uint64) ([]Conflict, error) {
+	return a.detectFor(context.Background(), accs, txIDs)
+}
+
+// DetectForWithContext behaves like DetectFor, but checks ctx between
+// accesses the same way DetectWithContext does.
+func (a *Arbitrator) DetectForWithContext(ctx context.Context, accs []Access, txIDs []uint64) ([]Conflict, error) {
+	return a.detectFor(ctx, accs, txIDs)
+}
+
+func (a *Arbitrator) detectFor(ctx context.Context, accs []Access, txIDs []uint64) ([]Conflict, error) {
+	if len(txIDs) == 0 {
+		return nil, nil
+	}
+	want := make(map[uint64]struct{}, len(txIDs))
+	for _, id := range txIDs {
+		want[id] = struct{}{}
+	}
+	filtered := make([]Access, 0, len(txIDs))
+	for _, acc := range accs {
+		if _, ok := want[acc.ID]; ok {
+			filtered = append(filtered, acc)
+		}
+	}
+	return a.detect(ctx, filtered, nil)
+}
+
+func (a *Arbitrator) detect(ctx context.Context, accs []Access, dst []Conflict) ([]Conflict, error) {
+	start := time.Now()
+	conflicts := dst[:0]
+	defer func() {
+		obs := a.obs()
+		obs.ObserveConflicts(len(conflicts))
+		obs.ObserveDetectLatency(time.Since(start))
+	}()
+	for _, acc := range accs {
+		if err := ctx.Err(); err != nil {
+			return conflicts, err
+		}
+		if limit := a.memoryLimit.Load(); limit > 0 && a.memoryUsage() > limit {
+			return conflicts, ErrMemoryLimitExceeded
+		}
+		for _, path := range acc.WriteSet {
+			if a.isConflictFree(path) {
+				conflicts = append(conflicts, a.recordCommutativeWrite(acc.ID, path, acc.CommutativeDeltas[path])...)
+				continue
+			}
+			conflicts = append(conflicts, a.recordWrite(acc.ID, path)...)
+		}
+		for _, pattern := range acc.WildcardWrites {
+			for _, path := range a.expandWildcard(pattern) {
+				conflicts = append(conflicts, a.recordWildcardWrite(acc.ID, path, pattern)...)
+			}
+		}
+		for _, path := range acc.CommutativeWrites {
+			conflicts = append(conflicts, a.recordCommutativeWrite(acc.ID, path, acc.CommutativeDeltas[path])...)
+		}
+		for _, path := range acc.ReadSet {
+			conflicts = append(conflicts, a.recordRead(acc.ID, path)...)
+		}
+	}
+	return conflicts, nil
+}
+
+// StateBlob is a standalone declared set of reads/writes — e.g. a
+// speculative execution diff — checked against an Access without ever
+// being recorded in the arbitrator's own dict.
+type StateBlob struct {
+	ID       uint64
+	ReadSet  []string
+	WriteSet []string
+}
+
+// DetectAgainst reports conflicts between acc and blob directly. It does
+// not touch, and is not affected by, the arbitrator's own dict — useful
+// for checking a transaction against a declared blob of anticipated state
+// changes in isolation, without polluting shared arbitration state.
+func (a *Arbitrator) DetectAgainst(acc Access, blob StateBlob) []Conflict {
+	blobWrites := toSet(blob.WriteSet)
+	accWrites := toSet(acc.WriteSet)
+
+	var conflicts []Conflict
+	for _, p := range acc.WriteSet {
+		if _, ok := blobWrites[p]; ok {
+			conflicts = append(conflicts, Conflict{A: blob.ID, B: acc.ID, Path: p, Reason: ReasonWriteWrite})
+		}
+	}
+	for _, p := range acc.ReadSet {
+		if _, ok := blobWrites[p]; ok {
+			conflicts = append(conflicts, Conflict{A: blob.ID, B: acc.ID, Path: p, Reason: ReasonReadWrite})
+		}
+	}
+	for _, p := range blob.ReadSet {
+		if _, ok := accWrites[p]; ok {
+			conflicts = append(conflicts, Conflict{A: acc.ID, B: blob.ID, Path: p, Reason: ReasonReadWrite})
+		}
+	}
+	return conflicts
+}
+
+func toSet(paths []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+// recordWrite records id's plain write to path within path's shard and
+// returns any conflicts it introduces against whatever was already
+// recorded there.
+func (a *Arbitrator) recordWrite(id uint64, path string) []Conflict {
+	sh := a.shardFor(path)
+	sh.mu.Lock()
+	e, isNew := sh.getOrCreate(path)
+	var conflicts []Conflict
+	if e.hasW && e.writer != id {
+		conflicts = append(conflicts, Conflict{A: e.writer, B: id, Path: path, Reason: ReasonWriteWrite})
+	}
+	for _, r := range e.readers {
+		if r != id {
+			conflicts = append(conflicts, Conflict{A: r, B: id, Path: path, Reason: ReasonReadWrite})
+		}
+	}
+	e.writer = id
+	e.hasW = true
+	e.commutative = false
+	sh.mu.Unlock()
+	if isNew {
+		a.trackNewEntry(path)
+		a.trackNewPath(path)
+	}
+	return conflicts
+}
+
+// recordWildcardWrite is recordWrite for a path reached by expanding
+// pattern, so the resulting Conflict carries ReasonWildcard plus the
+// pattern and issuing transaction that caused it.
+func (a *Arbitrator) recordWildcardWrite(id uint64, path, pattern string) []Conflict {
+	sh := a.shardFor(path)
+	sh.mu.Lock()
+	e, isNew := sh.getOrCreate(path)
+	var conflicts []Conflict
+	if e.hasW && e.writer != id {
+		conflicts = append(conflicts, Conflict{A: e.writer, B: id, Path: path, Reason: ReasonWildcard, WildcardPath: pattern, WildcardTx: id})
+	}
+	for _, r := range e.readers {
+		if r != id {
+			conflicts = append(conflicts, Conflict{A: r, B: id, Path: path, Reason: ReasonWildcard, WildcardPath: pattern, WildcardTx: id})
+		}
+	}
+	e.writer = id
+	e.hasW = true
+	sh.mu.Unlock()
+	if isNew {
+		a.trackNewEntry(path)
+		a.trackNewPath(path)
+	}
+	return conflicts
+}
+
+// recordRead records id's read of path within path's shard and returns
+// any conflict it introduces against a prior writer.
+func (a *Arbitrator) recordRead(id uint64, path string) []Conflict {
+	sh := a.shardFor(path)
+	sh.mu.Lock()
+	e, isNew := sh.getOrCreate(path)
+	var conflicts []Conflict
+	if e.hasW && e.writer != id {
+		conflicts = append(conflicts, Conflict{A: e.writer, B: id, Path: path, Reason: ReasonReadWrite})
+	}
+	e.readers = append(e.readers, id)
+	sh.mu.Unlock()
+	a.memBytes.Add(8)
+	if isNew {
+		a.trackNewEntry(path)
+		a.trackNewPath(path)
+	}
+	return conflicts
+}
+
+// recordCommutativeWrite records id's commutative write to path,
+// applying delta to path's running total if a BoundsChecker is
+// registered for it (see SetBoundsChecker), and returns any conflicts it
+// introduces against a prior plain access or a rejected delta.
+func (a *Arbitrator) recordCommutativeWrite(id uint64, path string, delta int64) []Conflict {
+	sh := a.shardFor(path)
+	sh.mu.Lock()
+	e, isNew := sh.getOrCreate(path)
+	var conflicts []Conflict
+	if e.hasW && e.writer != id && !e.commutative {
+		conflicts = append(conflicts, Conflict{A: e.writer, B: id, Path: path, Reason: ReasonWriteWrite})
+	}
+	for _, r := range e.readers {
+		if r != id {
+			conflicts = append(conflicts, Conflict{A: r, B: id, Path: path, Reason: ReasonReadWrite})
+		}
+	}
+	if checker := a.boundsCheckerFor(path); checker != nil {
+		if checker.CheckMinMax(e.total, delta) {
+			e.total += delta
+		} else {
+			offender := e.writer
+			if !e.hasW {
+				offender = id
+			}
+			conflicts = append(conflicts, Conflict{A: offender, B: id, Path: path, Reason: ReasonBoundsExceeded})
+		}
+	}
+	e.writer = id
+	e.hasW = true
+	e.commutative = true
+	sh.mu.Unlock()
+	if isNew {
+		a.trackNewEntry(path)
+		a.trackNewPath(path)
+	}
+	return conflicts
+}
+
+// trackNewPath adds path to any already-cached wildcard-prefix bucket it
+// matches. It is a no-op — a single atomic load, no locking — unless
+// MarkConflictFree or a wildcard expansion has registered at least one
+// hint or bucket, since most blocks never touch either feature.
+func (a *Arbitrator) trackNewPath(path string) {
+	if !a.hasHints.Load() {
+		return
+	}
+	a.mu.Lock()
+	for prefix, bucket := range a.prefixBuckets {
+		if strings.HasPrefix(path, prefix) {
+			bucket[path] = struct{}{}
+		}
+	}
+	a.mu.Unlock()
+}
+
+func (a *Arbitrator) knownPaths() []string {
+	var paths []string
+	for _, sh := range a.shards {
+		sh.mu.Lock()
+		for path := range sh.entries {
+			paths = append(paths, path)
+		}
+		sh.mu.Unlock()
+	}
+	return paths
+}
+
+// expandWildcard returns the concrete paths pattern currently matches. A
+// plain trailing wildcard (e.g. "container/*") is bucketized by prefix so
+// a clear-all against a large container costs one lookup per
+// already-known bucket member, not a rescan of every path the arbitrator
+// has ever seen; richer patterns — single-segment wildcards like
+// "/ctrn/*/balance", or ones with escaped literals — fall back to
+// matching against every known path, since they can't be reduced to a
+// single literal prefix.
+func (a *Arbitrator) expandWildcard(pattern string) []string {
+	compiled := wildcard.Compile(pattern)
+	if !compiled.IsWildcard() {
+		return wildcard.Expand(pattern, a.knownPaths())
+	}
+	if prefix, ok := compiled.SimplePrefix(); ok {
+		bucket := a.prefixBucket(prefix)
+		paths := make([]string, 0, len(bucket))
+		for path := range bucket {
+			paths = append(paths, path)
+		}
+		return paths
+	}
+	var paths []string
+	for _, path := range a.knownPaths() {
+		if compiled.Match(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// prefixBucket returns the set of known paths matching prefix, building
+// it with a full scan across every shard the first time prefix is
+// queried and reusing it on every later call. This, unlike plain
+// read/write recording, is not sharded: wildcard expansion is rare
+// enough relative to plain accesses that it isn't worth the complexity
+// of merging partial per-shard prefix indexes.
+func (a *Arbitrator) prefixBucket(prefix string) map[string]struct{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bucket, ok := a.prefixBuckets[prefix]
+	if !ok {
+		bucket = make(map[string]struct{})
+		for _, path := range a.knownPaths() {
+			if strings.HasPrefix(path, prefix) {
+				bucket[path] = struct{}{}
+			}
+		}
+		a.prefixBuckets[prefix] = bucket
+	}
+	a.hasHints.Store(true)
+	return bucket
+}
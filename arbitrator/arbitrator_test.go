@@ -0,0 +1,127 @@
+package arbitrator
+
+import "testing"
+
+func TestDetectWriteWriteConflict(t *testing.T) {
+	a := New()
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"p"}},
+		{ID: 2, WriteSet: []string{"p"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Reason != ReasonWriteWrite {
+		t.Fatalf("expected one write-write conflict, got %v", conflicts)
+	}
+}
+
+func TestDetectMemoryLimitExceeded(t *testing.T) {
+	a := New()
+	a.SetMemoryLimit(1)
+
+	_, err := a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"p1"}},
+		{ID: 2, WriteSet: []string{"p2"}},
+	})
+	if err != ErrMemoryLimitExceeded {
+		t.Fatalf("expected ErrMemoryLimitExceeded, got %v", err)
+	}
+}
+
+func TestDetectWildcardConflictHasDistinctReason(t *testing.T) {
+	a := New()
+	a.Detect([]Access{{ID: 1, WriteSet: []string{"container/1"}}})
+
+	conflicts, err := a.Detect([]Access{{ID: 2, WildcardWrites: []string{"container/*"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected one wildcard conflict, got %v", conflicts)
+	}
+	if conflicts[0].Reason != ReasonWildcard || conflicts[0].WildcardPath != "container/*" {
+		t.Fatalf("expected ReasonWildcard with original pattern, got %+v", conflicts[0])
+	}
+	if conflicts[0].WildcardTx != 2 {
+		t.Fatalf("expected WildcardTx to name the clear-all message, got %+v", conflicts[0])
+	}
+}
+
+func TestDetectWildcardBucketPicksUpPathsAddedAfterFirstExpansion(t *testing.T) {
+	a := New()
+	a.Detect([]Access{{ID: 1, WriteSet: []string{"container/1"}}})
+	a.Detect([]Access{{ID: 2, WildcardWrites: []string{"container/*"}}}) // first expansion builds the bucket
+
+	a.Detect([]Access{{ID: 3, WriteSet: []string{"container/2"}}}) // new member added after the bucket exists
+
+	conflicts, err := a.Detect([]Access{{ID: 4, WildcardWrites: []string{"container/*"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("expected the bucket to include the path added after the first expansion, got %v", conflicts)
+	}
+}
+
+func TestDetectCommutativeWritesDoNotConflict(t *testing.T) {
+	a := New()
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, CommutativeWrites: []string{"coinbase/balance"}},
+		{ID: 2, CommutativeWrites: []string{"coinbase/balance"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected commutative writes to the same path not to conflict, got %v", conflicts)
+	}
+}
+
+func TestDetectCommutativeWriteConflictsWithPlainWrite(t *testing.T) {
+	a := New()
+	a.Detect([]Access{{ID: 1, CommutativeWrites: []string{"coinbase/balance"}}})
+	conflicts, _ := a.Detect([]Access{{ID: 2, WriteSet: []string{"coinbase/balance"}}})
+	if len(conflicts) != 1 {
+		t.Fatalf("expected a plain write to conflict with a prior commutative write, got %v", conflicts)
+	}
+}
+
+func TestDetectAgainstFindsBlobConflictWithoutTouchingDict(t *testing.T) {
+	a := New()
+	blob := StateBlob{ID: 1, WriteSet: []string{"p"}}
+	conflicts := a.DetectAgainst(Access{ID: 2, WriteSet: []string{"p"}}, blob)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected one conflict against the blob, got %v", conflicts)
+	}
+	if a.MemoryUsage() != 0 {
+		t.Fatalf("expected DetectAgainst not to touch the arbitrator's dict, got usage %d", a.MemoryUsage())
+	}
+}
+
+func TestMarkConflictFreeAutoCommutesPlainWrites(t *testing.T) {
+	a := New()
+	a.MarkConflictFree("cumap/total")
+
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"cumap/total"}},
+		{ID: 2, WriteSet: []string{"cumap/total"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected conflict-free hint to auto-commute plain writes, got %v", conflicts)
+	}
+}
+
+func TestMemoryUsageGrowsWithPaths(t *testing.T) {
+	a := New()
+	if a.MemoryUsage() != 0 {
+		t.Fatalf("expected zero usage for empty arbitrator, got %d", a.MemoryUsage())
+	}
+	a.Detect([]Access{{ID: 1, WriteSet: []string{"p"}}})
+	if a.MemoryUsage() == 0 {
+		t.Fatal("expected non-zero usage after recording an access")
+	}
+}
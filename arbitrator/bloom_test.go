@@ -0,0 +1,57 @@
+package arbitrator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloomFastPathStillDetectsRepeatedPathConflicts(t *testing.T) {
+	a := New()
+	// First touch of "p" should take the bloom fast path (insert
+	// directly); the second touch must still find it and report the
+	// write-write conflict, proving the fast path never loses data.
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"p"}},
+		{ID: 2, WriteSet: []string{"p"}},
+	})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].A != 1 || conflicts[0].B != 2 {
+		t.Fatalf("expected one write-write conflict between 1 and 2, got %v", conflicts)
+	}
+}
+
+func TestBloomFilterHasNoFalseNegatives(t *testing.T) {
+	sh := &dictShard{entries: make(map[string]*entry)}
+	paths := make([]string, 2000)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("path/%d", i)
+	}
+	for _, p := range paths {
+		sh.getOrCreate(p)
+	}
+	for _, p := range paths {
+		h1, h2 := bloomHash(p)
+		if !sh.maybeSeen(h1, h2) {
+			t.Fatalf("bloom filter false negative for %q", p)
+		}
+		if _, ok := sh.entries[p]; !ok {
+			t.Fatalf("expected %q to have been inserted", p)
+		}
+	}
+}
+
+func BenchmarkDetectSingleTouchPaths(b *testing.B) {
+	accs := make([]Access, 1000)
+	for i := range accs {
+		accs[i] = Access{ID: uint64(i), WriteSet: []string{fmt.Sprintf("unique/%d", i)}}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a := New()
+		if _, err := a.Detect(accs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
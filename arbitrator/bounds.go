@@ -0,0 +1,68 @@
+package arbitrator
+
+import "github.com/arcology-network/scheduler/wildcard"
+
+// BoundsChecker validates that accumulating delta onto a commutative
+// path's running total stays within whatever invariant a custom
+// commutative type enforces (a bounded int64 counter, a set-size cap
+// where delta counts insertions/removals, or anything else the built-in
+// commutative handling in MarkConflictFree doesn't know how to check).
+// It is the pluggable counterpart to MarkConflictFree: marking a path
+// conflict-free says two commutative writes to it are always safe to run
+// concurrently, while a BoundsChecker on the same path additionally lets
+// Detect reject an accumulation that would leave the path out of range.
+type BoundsChecker interface {
+	// CheckMinMax reports whether applying delta to total (the path's
+	// running sum before this access) keeps it within bounds. total is 0
+	// the first time a path is seen within a block.
+	CheckMinMax(total, delta int64) bool
+}
+
+// MinMaxChecker is a ready-made BoundsChecker enforcing a closed
+// [Min, Max] range on the accumulated total — the common case a custom
+// commutative type would otherwise have to reimplement itself.
+type MinMaxChecker struct {
+	Min, Max int64
+}
+
+// CheckMinMax implements BoundsChecker.
+func (c MinMaxChecker) CheckMinMax(total, delta int64) bool {
+	next := total + delta
+	return next >= c.Min && next <= c.Max
+}
+
+type boundsHint struct {
+	pattern string
+	checker BoundsChecker
+}
+
+// SetBoundsChecker registers checker against pathOrPrefix (a literal path
+// or a "prefix*" wildcard, matched the same way MarkConflictFree matches
+// its hints), so every commutative write to a matching path has its
+// delta validated through checker instead of being accepted
+// unconditionally.
+func (a *Arbitrator) SetBoundsChecker(pathOrPrefix string, checker BoundsChecker) {
+	a.mu.Lock()
+	a.boundsHints = append(a.boundsHints, boundsHint{pattern: pathOrPrefix, checker: checker})
+	a.mu.Unlock()
+	a.hasBoundsHints.Store(true)
+}
+
+// boundsCheckerFor returns the BoundsChecker registered for path, or nil
+// if none matches.
+func (a *Arbitrator) boundsCheckerFor(path string) BoundsChecker {
+	if !a.hasBoundsHints.Load() {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, hint := range a.boundsHints {
+		if hint.pattern == path {
+			return hint.checker
+		}
+		if p := wildcard.Compile(hint.pattern); p.IsWildcard() && p.Covers(path) {
+			return hint.checker
+		}
+	}
+	return nil
+}
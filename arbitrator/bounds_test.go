@@ -0,0 +1,60 @@
+package arbitrator
+
+import "testing"
+
+func TestBoundsCheckerRejectsADeltaThatExceedsMax(t *testing.T) {
+	a := New()
+	a.SetBoundsChecker("counter/x", MinMaxChecker{Min: 0, Max: 100})
+
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 60}},
+		{ID: 2, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 60}},
+	})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	found := false
+	for _, c := range conflicts {
+		if c.Reason == ReasonBoundsExceeded {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a ReasonBoundsExceeded conflict when the running total would exceed Max, got %+v", conflicts)
+	}
+}
+
+func TestBoundsCheckerAllowsDeltasWithinRange(t *testing.T) {
+	a := New()
+	a.SetBoundsChecker("counter/x", MinMaxChecker{Min: 0, Max: 100})
+
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 30}},
+		{ID: 2, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 30}},
+	})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	for _, c := range conflicts {
+		if c.Reason == ReasonBoundsExceeded {
+			t.Fatalf("expected no bounds conflict for deltas within range, got %+v", conflicts)
+		}
+	}
+}
+
+func TestNoBoundsCheckerRegisteredNeverFlagsBoundsExceeded(t *testing.T) {
+	a := New()
+
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 1000}},
+		{ID: 2, CommutativeWrites: []string{"counter/x"}, CommutativeDeltas: map[string]int64{"counter/x": 1000}},
+	})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	for _, c := range conflicts {
+		if c.Reason == ReasonBoundsExceeded {
+			t.Fatalf("expected no bounds conflict without a registered BoundsChecker, got %+v", conflicts)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package arbitrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDetectWithContextReportsConflictsWhenNotCanceled(t *testing.T) {
+	a := New()
+	conflicts, err := a.DetectWithContext(context.Background(), []Access{
+		{ID: 1, WriteSet: []string{"p"}},
+		{ID: 2, WriteSet: []string{"p"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Reason != ReasonWriteWrite {
+		t.Fatalf("expected one write-write conflict, got %v", conflicts)
+	}
+}
+
+func TestDetectWithContextAbortsOnCanceledContext(t *testing.T) {
+	a := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	accs := make([]Access, 0, 10)
+	for i := 0; i < 10; i++ {
+		accs = append(accs, Access{ID: uint64(i + 1), WriteSet: []string{"p"}})
+	}
+	conflicts, err := a.DetectWithContext(ctx, accs)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts before the first access is processed, got %v", conflicts)
+	}
+}
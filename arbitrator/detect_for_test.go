@@ -0,0 +1,51 @@
+package arbitrator
+
+import "testing"
+
+func TestDetectForOnlyArbitratesTheGivenTxIDs(t *testing.T) {
+	a := New()
+
+	conflicts, err := a.DetectFor([]Access{
+		{ID: 1, WriteSet: []string{"a"}},
+		{ID: 2, WriteSet: []string{"a"}},
+		{ID: 3, WriteSet: []string{"a"}},
+	}, []uint64{1, 2})
+	if err != nil {
+		t.Fatalf("DetectFor: %v", err)
+	}
+	for _, c := range conflicts {
+		if c.A == 3 || c.B == 3 {
+			t.Fatalf("expected transaction 3 to be excluded, got %+v", conflicts)
+		}
+	}
+	if len(conflicts) != 1 || (conflicts[0].A != 1 && conflicts[0].B != 1) || (conflicts[0].A != 2 && conflicts[0].B != 2) {
+		t.Fatalf("expected a single conflict between 1 and 2, got %+v", conflicts)
+	}
+}
+
+func TestDetectForWithNoMatchingTxIDsReturnsNoConflicts(t *testing.T) {
+	a := New()
+
+	conflicts, err := a.DetectFor([]Access{
+		{ID: 1, WriteSet: []string{"a"}},
+		{ID: 2, WriteSet: []string{"a"}},
+	}, []uint64{99})
+	if err != nil {
+		t.Fatalf("DetectFor: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts when no txID matches, got %+v", conflicts)
+	}
+}
+
+func TestDetectForEmptyTxIDsIsANoOp(t *testing.T) {
+	a := New()
+
+	conflicts, err := a.DetectFor([]Access{{ID: 1, WriteSet: []string{"a"}}}, nil)
+	if err != nil {
+		t.Fatalf("DetectFor: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts for an empty txIDs slice, got %+v", conflicts)
+	}
+}
@@ -0,0 +1,44 @@
+package arbitrator
+
+// GroupPair identifies an unordered pair of group IDs (GroupA <= GroupB)
+// that had at least one conflict between them. A pair with GroupA ==
+// GroupB counts conflicts found within that single group.
+type GroupPair struct {
+	GroupA, GroupB uint64
+}
+
+// GroupConflicts aggregates conflicts by the GroupID each side's
+// transaction was tagged with via Access.GroupID, returning the number
+// of conflicts found for every distinct pair of groups touched.
+//
+// Earlier attempts at this aggregated by indexing a per-access groupID
+// slice positionally against the conflict list, which panicked whenever
+// the two slices' lengths diverged and silently produced garbage
+// otherwise (the slice was never actually populated). GroupConflicts
+// avoids both problems by building an explicit transaction ID -> GroupID
+// lookup from accs first, so a conflict referencing a transaction not in
+// accs is simply skipped rather than indexed out of bounds. An Access
+// that never set GroupID counts as group 0.
+func GroupConflicts(conflicts []Conflict, accs []Access) map[GroupPair]int {
+	groupOf := make(map[uint64]uint64, len(accs))
+	for _, acc := range accs {
+		groupOf[acc.ID] = acc.GroupID
+	}
+
+	dict := make(map[GroupPair]int, len(conflicts))
+	for _, c := range conflicts {
+		ga, ok := groupOf[c.A]
+		if !ok {
+			continue
+		}
+		gb, ok := groupOf[c.B]
+		if !ok {
+			continue
+		}
+		if ga > gb {
+			ga, gb = gb, ga
+		}
+		dict[GroupPair{GroupA: ga, GroupB: gb}]++
+	}
+	return dict
+}
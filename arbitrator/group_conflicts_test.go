@@ -0,0 +1,52 @@
+package arbitrator
+
+import "testing"
+
+func TestGroupConflictsAggregatesByAccessGroupID(t *testing.T) {
+	accs := []Access{
+		{ID: 1, GroupID: 10, WriteSet: []string{"a"}},
+		{ID: 2, GroupID: 10, WriteSet: []string{"a"}},
+		{ID: 3, GroupID: 20, WriteSet: []string{"a"}},
+	}
+	a := New()
+	conflicts, err := a.Detect(accs)
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+
+	dict := GroupConflicts(conflicts, accs)
+	if dict[GroupPair{GroupA: 10, GroupB: 10}] != 1 {
+		t.Fatalf("expected 1 conflict within group 10, got %v", dict)
+	}
+	if dict[GroupPair{GroupA: 10, GroupB: 20}] != 1 {
+		t.Fatalf("expected 1 cross-group conflict between 10 and 20, got %v", dict)
+	}
+}
+
+func TestGroupConflictsNormalizesPairOrder(t *testing.T) {
+	accs := []Access{
+		{ID: 1, GroupID: 5, WriteSet: []string{"a"}},
+		{ID: 2, GroupID: 1, WriteSet: []string{"a"}},
+	}
+	a := New()
+	conflicts, err := a.Detect(accs)
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+
+	dict := GroupConflicts(conflicts, accs)
+	if dict[GroupPair{GroupA: 1, GroupB: 5}] != 1 {
+		t.Fatalf("expected the pair to be normalized to (1, 5), got %v", dict)
+	}
+	if _, ok := dict[GroupPair{GroupA: 5, GroupB: 1}]; ok {
+		t.Fatalf("did not expect an unnormalized (5, 1) key, got %v", dict)
+	}
+}
+
+func TestGroupConflictsSkipsConflictsForUnknownTransactions(t *testing.T) {
+	accs := []Access{{ID: 1, GroupID: 1}}
+	dict := GroupConflicts([]Conflict{{A: 1, B: 99}}, accs)
+	if len(dict) != 0 {
+		t.Fatalf("expected no aggregation for a conflict referencing an unknown transaction, got %v", dict)
+	}
+}
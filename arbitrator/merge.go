@@ -0,0 +1,26 @@
+package arbitrator
+
+// MergeConflicts combines the Conflicts output of several independent
+// Arbitrators — e.g. one per account shard or execution unit in a
+// partitioned deployment — into a single deduplicated, canonically
+// ordered report. Each shard only ever sees its own slice of paths, so
+// the same logical conflict can otherwise surface twice (once from each
+// side) or with A and B swapped depending on which shard detected it
+// first.
+func MergeConflicts(shards ...[]Conflict) []Conflict {
+	seen := make(map[Conflict]struct{})
+	var merged []Conflict
+	for _, shard := range shards {
+		for _, c := range shard {
+			if c.A > c.B {
+				c.A, c.B = c.B, c.A
+			}
+			if _, ok := seen[c]; ok {
+				continue
+			}
+			seen[c] = struct{}{}
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
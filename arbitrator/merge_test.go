@@ -0,0 +1,26 @@
+package arbitrator
+
+import "testing"
+
+func TestMergeConflictsDeduplicatesAcrossShards(t *testing.T) {
+	shardA := []Conflict{{A: 1, B: 2, Path: "p", Reason: ReasonWriteWrite}}
+	shardB := []Conflict{{A: 2, B: 1, Path: "p", Reason: ReasonWriteWrite}}
+
+	merged := MergeConflicts(shardA, shardB)
+	if len(merged) != 1 {
+		t.Fatalf("expected the swapped duplicate to be merged away, got %v", merged)
+	}
+	if merged[0].A != 1 || merged[0].B != 2 {
+		t.Fatalf("expected canonical A<B ordering, got %+v", merged[0])
+	}
+}
+
+func TestMergeConflictsKeepsDistinctConflicts(t *testing.T) {
+	shardA := []Conflict{{A: 1, B: 2, Path: "p1", Reason: ReasonWriteWrite}}
+	shardB := []Conflict{{A: 3, B: 4, Path: "p2", Reason: ReasonReadWrite}}
+
+	merged := MergeConflicts(shardA, shardB)
+	if len(merged) != 2 {
+		t.Fatalf("expected both distinct conflicts to survive, got %v", merged)
+	}
+}
@@ -0,0 +1,34 @@
+package arbitrator
+
+import (
+	"time"
+
+	"testing"
+)
+
+type recordingObserver struct {
+	conflicts int
+}
+
+func (r *recordingObserver) ObserveCalleeCount(int)             {}
+func (r *recordingObserver) ObserveGenerations(int)             {}
+func (r *recordingObserver) ObserveParallelWidth(int)           {}
+func (r *recordingObserver) ObserveDeferred(int)                {}
+func (r *recordingObserver) ObserveNewLatency(time.Duration)    {}
+func (r *recordingObserver) ObserveConflicts(n int)             { r.conflicts = n }
+func (r *recordingObserver) ObserveDetectLatency(time.Duration) {}
+
+func TestDetectReportsConflictCountToObserver(t *testing.T) {
+	a := New()
+	obs := &recordingObserver{}
+	a.SetObserver(obs)
+
+	a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"p"}},
+		{ID: 2, WriteSet: []string{"p"}},
+	})
+
+	if obs.conflicts != 1 {
+		t.Fatalf("expected 1 conflict observed, got %d", obs.conflicts)
+	}
+}
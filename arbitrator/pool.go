@@ -0,0 +1,80 @@
+package arbitrator
+
+import "sync"
+
+// entryPool recycles the *entry structs dictShard.getOrCreate allocates
+// for each distinct path touched during a block, so Reset can hand them
+// back for reuse by the next block instead of leaving them for the GC.
+var entryPool = sync.Pool{New: func() interface{} { return &entry{} }}
+
+// pool recycles whole Arbitrators via Get/Put, on top of the per-entry
+// pooling Reset already does, for callers that build a fresh Arbitrator
+// once per block at high TPS and would otherwise pay for a new dict and
+// prefixBuckets map every time.
+var pool = sync.Pool{New: func() interface{} { return New() }}
+
+// Reset clears a's per-block state — the path dict and prefix-wildcard
+// buckets accumulated by Detect — while keeping their underlying map
+// capacity, and returns their *entry structs to entryPool for the next
+// block to reuse. It leaves persistent configuration (SetMemoryLimit,
+// SetObserver, MarkConflictFree) untouched, since those describe the
+// runtime rather than any one block's accesses.
+func (a *Arbitrator) Reset() {
+	a.resetState()
+}
+
+// Reuse resets a's per-block state exactly like Reset, under the name a
+// caller cycling the same Arbitrator through consecutive blocks would
+// look for. Unlike a naive clear that drops and reallocates the wildcard
+// bucket maps built up by prefix expansion of wildcard writes, Reuse
+// (via resetState) empties each bucket in place, so its allocated
+// capacity — along with the per-path entry dict's, already pooled by
+// Reset — carries over to the next block instead of being reallocated
+// from scratch every cycle.
+func (a *Arbitrator) Reuse() {
+	a.resetState()
+}
+
+func (a *Arbitrator) resetState() {
+	for _, sh := range a.shards {
+		sh.mu.Lock()
+		for path, e := range sh.entries {
+			e.writer = 0
+			e.hasW = false
+			e.commutative = false
+			e.total = 0
+			e.readers = e.readers[:0]
+			entryPool.Put(e)
+			delete(sh.entries, path)
+		}
+		for i := range sh.bloom {
+			sh.bloom[i] = 0
+		}
+		sh.mu.Unlock()
+	}
+	a.mu.Lock()
+	for _, bucket := range a.prefixBuckets {
+		for path := range bucket {
+			delete(bucket, path)
+		}
+	}
+	a.mu.Unlock()
+	a.memBytes.Store(0)
+}
+
+// Get returns an Arbitrator ready to arbitrate a new block, reused from a
+// package-level pool when one is available instead of allocating a fresh
+// dict and prefixBuckets map. Its persistent configuration (memory limit,
+// observer, conflict-free hints) carries over from whichever earlier user
+// last called Put on it; callers that need a guaranteed-blank
+// configuration should call New instead.
+func Get() *Arbitrator {
+	return pool.Get().(*Arbitrator)
+}
+
+// Put resets a and returns it to the package-level pool for a future Get
+// call to reuse. Callers must not use a again after calling Put.
+func Put(a *Arbitrator) {
+	a.Reset()
+	pool.Put(a)
+}
@@ -0,0 +1,104 @@
+package arbitrator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResetClearsDictButKeepsConfig(t *testing.T) {
+	a := New()
+	a.SetMemoryLimit(1000)
+	a.MarkConflictFree("commutative/*")
+
+	if _, err := a.Detect([]Access{{ID: 1, WriteSet: []string{"p"}}}); err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if a.MemoryUsage() == 0 {
+		t.Fatal("expected MemoryUsage to be nonzero before Reset")
+	}
+
+	a.Reset()
+
+	if a.MemoryUsage() != 0 {
+		t.Fatalf("expected Reset to clear the dict, MemoryUsage=%d", a.MemoryUsage())
+	}
+	if a.memoryLimit.Load() != 1000 {
+		t.Fatalf("expected Reset to preserve the memory limit, got %d", a.memoryLimit.Load())
+	}
+	if len(a.conflictFree) != 1 {
+		t.Fatalf("expected Reset to preserve conflict-free hints, got %v", a.conflictFree)
+	}
+}
+
+func TestResetAllowsPathsToBeReusedAcrossBlocks(t *testing.T) {
+	a := New()
+	a.Detect([]Access{{ID: 1, WriteSet: []string{"p"}}})
+	a.Reset()
+
+	conflicts, err := a.Detect([]Access{{ID: 2, WriteSet: []string{"p"}}})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflict against a reset dict, got %v", conflicts)
+	}
+}
+
+func TestGetPutRoundTripsThroughThePool(t *testing.T) {
+	a := Get()
+	a.Detect([]Access{{ID: 1, WriteSet: []string{"p"}}})
+	Put(a)
+
+	b := Get()
+	if b.MemoryUsage() != 0 {
+		t.Fatalf("expected a pooled Arbitrator to come back Reset, MemoryUsage=%d", b.MemoryUsage())
+	}
+}
+
+func TestDetectIntoAppendsOntoAReusedBuffer(t *testing.T) {
+	a := New()
+	buf := make([]Conflict, 0, 8)
+
+	buf, err := a.DetectInto(context.Background(), []Access{{ID: 1, WriteSet: []string{"p"}}}, buf)
+	if err != nil {
+		t.Fatalf("DetectInto: %v", err)
+	}
+	buf, err = a.DetectInto(context.Background(), []Access{{ID: 2, WriteSet: []string{"p"}}}, buf[:0])
+	if err != nil {
+		t.Fatalf("DetectInto: %v", err)
+	}
+	if len(buf) != 1 || buf[0].A != 1 || buf[0].B != 2 {
+		t.Fatalf("expected one write-write conflict between 1 and 2, got %v", buf)
+	}
+}
+
+func BenchmarkDetectFreshArbitratorPerBlock(b *testing.B) {
+	accs := benchAccesses(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a := New()
+		if _, err := a.Detect(accs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDetectPooledArbitrator(b *testing.B) {
+	accs := benchAccesses(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a := Get()
+		if _, err := a.Detect(accs); err != nil {
+			b.Fatal(err)
+		}
+		Put(a)
+	}
+}
+
+func benchAccesses(n int) []Access {
+	accs := make([]Access, n)
+	for i := range accs {
+		accs[i] = Access{ID: uint64(i), WriteSet: []string{"path"}, ReadSet: []string{"other"}}
+	}
+	return accs
+}
@@ -0,0 +1,36 @@
+package arbitrator
+
+// String renders r as a stable, lowercase identifier suitable for logs
+// and metrics labels — never for callers to branch on; use the IsXxx
+// accessors below or a switch over the Reason value itself for that.
+func (r Reason) String() string {
+	switch r {
+	case ReasonWriteWrite:
+		return "write-write"
+	case ReasonReadWrite:
+		return "read-write"
+	case ReasonWildcard:
+		return "wildcard"
+	case ReasonBoundsExceeded:
+		return "bounds-exceeded"
+	default:
+		return "unknown"
+	}
+}
+
+// IsWriteWrite reports whether c was flagged because both messages wrote
+// the same path.
+func (c Conflict) IsWriteWrite() bool { return c.Reason == ReasonWriteWrite }
+
+// IsReadWrite reports whether c was flagged because one message read a
+// path the other wrote.
+func (c Conflict) IsReadWrite() bool { return c.Reason == ReasonReadWrite }
+
+// IsWildcard reports whether c was introduced by expanding a wildcard
+// write against a concrete path, rather than by two literal accesses.
+func (c Conflict) IsWildcard() bool { return c.Reason == ReasonWildcard }
+
+// IsBoundsExceeded reports whether c was flagged because a commutative
+// write's delta would have pushed a BoundsChecker-guarded path's running
+// total out of range.
+func (c Conflict) IsBoundsExceeded() bool { return c.Reason == ReasonBoundsExceeded }
@@ -0,0 +1,31 @@
+package arbitrator
+
+import "testing"
+
+func TestReasonStringIsStableAndLowercase(t *testing.T) {
+	cases := map[Reason]string{
+		ReasonWriteWrite:     "write-write",
+		ReasonReadWrite:      "read-write",
+		ReasonWildcard:       "wildcard",
+		ReasonBoundsExceeded: "bounds-exceeded",
+	}
+	for reason, want := range cases {
+		if got := reason.String(); got != want {
+			t.Fatalf("Reason(%d).String() = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestConflictAccessorsMatchTheirReason(t *testing.T) {
+	c := Conflict{Reason: ReasonWildcard}
+	if c.IsWildcard() != true || c.IsWriteWrite() != false || c.IsReadWrite() != false {
+		t.Fatalf("expected only IsWildcard to report true for %+v", c)
+	}
+}
+
+func TestConflictIsBoundsExceededMatchesItsReason(t *testing.T) {
+	c := Conflict{Reason: ReasonBoundsExceeded}
+	if c.IsBoundsExceeded() != true || c.IsWriteWrite() != false || c.IsReadWrite() != false || c.IsWildcard() != false {
+		t.Fatalf("expected only IsBoundsExceeded to report true for %+v", c)
+	}
+}
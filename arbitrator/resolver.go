@@ -0,0 +1,75 @@
+package arbitrator
+
+import "math/rand"
+
+// ResolutionStrategy selects how a Resolver picks a winner out of a
+// Conflict's two transactions.
+type ResolutionStrategy int
+
+const (
+	// ResolveKeepEarliest always keeps Conflict.A, the party that was
+	// already recorded in the arbitrator's dict when the conflict was
+	// raised. This is the behavior Detect implied on its own before
+	// Resolver existed, kept as the default so callers that don't care
+	// about resolution see no change.
+	ResolveKeepEarliest ResolutionStrategy = iota
+	// ResolveKeepHighestScore keeps whichever of A or B Scorer rates
+	// higher (e.g. by gas price), breaking ties in favor of A.
+	ResolveKeepHighestScore
+	// ResolveWeightedRandom keeps A or B with probability proportional to
+	// their Scorer weight, so a transaction with more at stake is more
+	// likely, but not certain, to survive.
+	ResolveWeightedRandom
+)
+
+// Scorer rates a transaction ID for ResolveKeepHighestScore and
+// ResolveWeightedRandom — e.g. by looking up its gas price. Higher is
+// more likely to survive.
+type Scorer func(id uint64) float64
+
+// Resolver decides, for each Conflict a caller has detected, which of the
+// two transactions to keep and which to drop from the current batch (by
+// dropping it, or deferring it to a later one). Score and Rand are only
+// consulted by the strategies that need them; ResolveKeepEarliest ignores
+// both.
+type Resolver struct {
+	Strategy ResolutionStrategy
+	Score    Scorer
+	Rand     *rand.Rand
+}
+
+// Resolve returns the transaction ID from c that should be kept and the
+// one that should be dropped, per r.Strategy.
+func (r *Resolver) Resolve(c Conflict) (keep, drop uint64) {
+	switch r.Strategy {
+	case ResolveKeepHighestScore:
+		if r.Score(c.B) > r.Score(c.A) {
+			return c.B, c.A
+		}
+		return c.A, c.B
+	case ResolveWeightedRandom:
+		weightA, weightB := r.Score(c.A), r.Score(c.B)
+		if weightA+weightB <= 0 {
+			return c.A, c.B
+		}
+		if r.Rand.Float64() < weightA/(weightA+weightB) {
+			return c.A, c.B
+		}
+		return c.B, c.A
+	default:
+		return c.A, c.B
+	}
+}
+
+// ResolveLosers applies r across every conflict in conflicts and returns
+// the set of transaction IDs that lost at least one of them, so a caller
+// can drop or defer all of them from the current batch in one pass
+// instead of resolving conflicts one at a time.
+func (r *Resolver) ResolveLosers(conflicts []Conflict) map[uint64]struct{} {
+	losers := make(map[uint64]struct{})
+	for _, c := range conflicts {
+		_, drop := r.Resolve(c)
+		losers[drop] = struct{}{}
+	}
+	return losers
+}
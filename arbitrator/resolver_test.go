@@ -0,0 +1,66 @@
+package arbitrator
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestResolveKeepEarliestAlwaysKeepsA(t *testing.T) {
+	r := &Resolver{}
+	keep, drop := r.Resolve(Conflict{A: 1, B: 2})
+	if keep != 1 || drop != 2 {
+		t.Fatalf("expected to keep A=1, got keep=%d drop=%d", keep, drop)
+	}
+}
+
+func TestResolveKeepHighestScorePicksHigherScore(t *testing.T) {
+	scores := map[uint64]float64{1: 10, 2: 99}
+	r := &Resolver{Strategy: ResolveKeepHighestScore, Score: func(id uint64) float64 { return scores[id] }}
+	keep, drop := r.Resolve(Conflict{A: 1, B: 2})
+	if keep != 2 || drop != 1 {
+		t.Fatalf("expected to keep the higher-scored B=2, got keep=%d drop=%d", keep, drop)
+	}
+}
+
+func TestResolveKeepHighestScoreBreaksTiesTowardA(t *testing.T) {
+	r := &Resolver{Strategy: ResolveKeepHighestScore, Score: func(id uint64) float64 { return 5 }}
+	keep, _ := r.Resolve(Conflict{A: 1, B: 2})
+	if keep != 1 {
+		t.Fatalf("expected a tie to favor A, got keep=%d", keep)
+	}
+}
+
+func TestResolveWeightedRandomFavorsHigherWeightOverManyTrials(t *testing.T) {
+	scores := map[uint64]float64{1: 90, 2: 10}
+	r := &Resolver{
+		Strategy: ResolveWeightedRandom,
+		Score:    func(id uint64) float64 { return scores[id] },
+		Rand:     rand.New(rand.NewSource(1)),
+	}
+	winsA := 0
+	for i := 0; i < 1000; i++ {
+		if keep, _ := r.Resolve(Conflict{A: 1, B: 2}); keep == 1 {
+			winsA++
+		}
+	}
+	if winsA < 700 {
+		t.Fatalf("expected the heavily-weighted A to win most trials, got %d/1000", winsA)
+	}
+}
+
+func TestResolveLosersDedupsAcrossMultipleConflicts(t *testing.T) {
+	r := &Resolver{}
+	losers := r.ResolveLosers([]Conflict{
+		{A: 1, B: 2},
+		{A: 1, B: 3},
+	})
+	if len(losers) != 2 {
+		t.Fatalf("expected 2 distinct losers, got %v", losers)
+	}
+	if _, ok := losers[2]; !ok {
+		t.Fatal("expected 2 to be a loser")
+	}
+	if _, ok := losers[3]; !ok {
+		t.Fatal("expected 3 to be a loser")
+	}
+}
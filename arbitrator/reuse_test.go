@@ -0,0 +1,49 @@
+package arbitrator
+
+import "testing"
+
+func TestReuseProducesIndependentResultsAcrossBlocks(t *testing.T) {
+	a := New()
+	a.MarkConflictFree("commutative/*")
+
+	if _, err := a.Detect([]Access{{ID: 1, WriteSet: []string{"p"}}}); err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	a.Reuse()
+
+	conflicts, err := a.Detect([]Access{{ID: 2, WriteSet: []string{"p"}}})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected block 2 to see no conflict left over from block 1, got %v", conflicts)
+	}
+	if len(a.conflictFree) != 1 {
+		t.Fatalf("expected Reuse to preserve conflict-free hints, got %v", a.conflictFree)
+	}
+}
+
+func TestReuseClearsWildcardBucketsBetweenBlocks(t *testing.T) {
+	a := New()
+
+	conflicts, err := a.Detect([]Access{
+		{ID: 1, WriteSet: []string{"container/1"}},
+		{ID: 2, WildcardWrites: []string{"container/*"}},
+	})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) == 0 {
+		t.Fatal("expected a wildcard write to conflict with an earlier concrete write")
+	}
+
+	a.Reuse()
+
+	conflicts, err = a.Detect([]Access{{ID: 3, WriteSet: []string{"container/1"}}})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected the wildcard bucket from block 1 to be gone after Reuse, got %v", conflicts)
+	}
+}
@@ -0,0 +1,91 @@
+package arbitrator
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// numShards partitions the arbitrator's path dict across this many
+// independently locked buckets. Two accesses only ever conflict when
+// they touch the exact same path, and a path always hashes to exactly
+// one shard, so splitting the dict this way never hides a real conflict
+// — it only lets accesses to different paths proceed without contending
+// on the same lock, which is what lets Detect calls (and Detect calls
+// running concurrently against one shared Arbitrator) scale across
+// cores on a block with many distinct paths.
+const numShards = 32
+
+// bloomWords sizes each shard's bloom filter at 8192 bits (1KB), split
+// across numShards buckets that carry roughly 1/32 of a block's distinct
+// paths each — comfortably low false-positive territory for blocks up to
+// a few hundred thousand total paths.
+const bloomWords = 128
+
+type dictShard struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	bloom   [bloomWords]uint64
+}
+
+func newShards() []*dictShard {
+	shards := make([]*dictShard, numShards)
+	for i := range shards {
+		shards[i] = &dictShard{entries: make(map[string]*entry)}
+	}
+	return shards
+}
+
+// shardFor returns the shard path always hashes to.
+func (a *Arbitrator) shardFor(path string) *dictShard {
+	h := fnv.New32a()
+	h.Write([]byte(path))
+	return a.shards[h.Sum32()%numShards]
+}
+
+// bloomHash returns two independent-enough hashes of path, computed from
+// a single fnv32a pass by folding it against its own byte-swap — cheaper
+// than running two separate hash functions over the string.
+func bloomHash(path string) (h1, h2 uint32) {
+	h := fnv.New32a()
+	h.Write([]byte(path))
+	sum := h.Sum32()
+	return sum, sum>>16 | sum<<16
+}
+
+// maybeSeen reports whether path might already have an entry in sh,
+// using its two bloom bits. A false answer is a guarantee the path has
+// never been inserted, letting getOrCreate skip the map lookup entirely
+// for the common case of a path touched only once per block. A true
+// answer only means "maybe" — false positives are expected and getOrCreate
+// falls back to the real map lookup for them.
+func (sh *dictShard) maybeSeen(h1, h2 uint32) bool {
+	bit1, bit2 := h1%(bloomWords*64), h2%(bloomWords*64)
+	return sh.bloom[bit1/64]&(1<<(bit1%64)) != 0 && sh.bloom[bit2/64]&(1<<(bit2%64)) != 0
+}
+
+func (sh *dictShard) markSeen(h1, h2 uint32) {
+	bit1, bit2 := h1%(bloomWords*64), h2%(bloomWords*64)
+	sh.bloom[bit1/64] |= 1 << (bit1 % 64)
+	sh.bloom[bit2/64] |= 1 << (bit2 % 64)
+}
+
+// getOrCreate returns the entry for path within the shard, pulling one
+// from entryPool if this is the first access to it. The caller must hold
+// sh.mu for the duration of the returned entry's use. It consults the
+// shard's bloom filter first: when the filter guarantees path has never
+// been inserted, it skips the map lookup and goes straight to insertion,
+// which is the common case since most paths in a block are touched by
+// exactly one transaction.
+func (sh *dictShard) getOrCreate(path string) (e *entry, isNew bool) {
+	h1, h2 := bloomHash(path)
+	if sh.maybeSeen(h1, h2) {
+		if e, ok := sh.entries[path]; ok {
+			return e, false
+		}
+	} else {
+		sh.markSeen(h1, h2)
+	}
+	e = entryPool.Get().(*entry)
+	sh.entries[path] = e
+	return e, true
+}
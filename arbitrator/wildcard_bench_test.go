@@ -0,0 +1,36 @@
+package arbitrator
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkDetectWildcardClearAll exercises the case flagged as the
+// slowest in arbitration: an L2-style batch that writes many keys into
+// one container and then a single transaction clears it all with a
+// wildcard write. Before prefix bucketization, every clear-all rescanned
+// every known path in the arbitrator to find the container's members;
+// now a bucket keyed by the container's prefix is built once and reused,
+// so repeated clears against the same container are O(bucket) instead of
+// O(all known paths).
+func BenchmarkDetectWildcardClearAll(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("keys=%d", n), func(b *testing.B) {
+			a := New()
+			accs := make([]Access, 0, n)
+			for i := 0; i < n; i++ {
+				accs = append(accs, Access{ID: uint64(i), WriteSet: []string{fmt.Sprintf("container/%d", i)}})
+			}
+			if _, err := a.Detect(accs); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := a.Detect([]Access{{ID: uint64(n) + uint64(i), WildcardWrites: []string{"container/*"}}}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
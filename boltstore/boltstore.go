@@ -0,0 +1,139 @@
+// Package boltstore provides a bbolt-backed scheduler.CalleeStore, an
+// embedded single-file alternative to package leveldbstore for callers
+// that already depend on bbolt elsewhere or want its stricter ACID
+// transaction guarantees. Like leveldbstore, each Set updates a single
+// key rather than rewriting a serialized Callees slice on every save.
+package boltstore
+
+import (
+	"encoding/json"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/arcology-network/scheduler"
+)
+
+// calleesBucket holds every persisted Callee, keyed the same way
+// scheduler.CalleeStore is everywhere else: "<address>:<signature>".
+var calleesBucket = []byte("callees")
+
+// Store is a scheduler.CalleeStore backed by an on-disk bbolt database.
+// Because entries are serialized to and from bytes, Get returns a
+// distinct *scheduler.Callee value on every call rather than a shared
+// pointer; callers that mutate the returned Callee must Set it back to
+// persist the change.
+type Store struct {
+	mu  sync.Mutex
+	db  *bolt.DB
+	err error
+}
+
+var _ scheduler.CalleeStore = (*Store)(nil)
+
+// Open opens (creating if necessary) a bbolt database at path and
+// returns a Store backed by it. The caller is responsible for calling
+// Close when done with it.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(calleesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying bbolt database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Err returns the most recent error encountered by Get, Set, ForEach, or
+// Len, since the scheduler.CalleeStore interface has no room for one.
+func (s *Store) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Store) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// Get looks up key and decodes the Callee stored under it, if any.
+func (s *Store) Get(key string) (*scheduler.Callee, bool) {
+	var c scheduler.Callee
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(calleesBucket).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+	if err != nil {
+		s.setErr(err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+	return &c, true
+}
+
+// Set encodes c and writes it under key in its own transaction, touching
+// only that one key.
+func (s *Store) Set(key string, c *scheduler.Callee) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		s.setErr(err)
+		return
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(calleesBucket).Put([]byte(key), raw)
+	}); err != nil {
+		s.setErr(err)
+	}
+}
+
+// ForEach decodes and visits every entry currently in the database.
+func (s *Store) ForEach(fn func(key string, c *scheduler.Callee)) {
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(calleesBucket).ForEach(func(k, v []byte) error {
+			var c scheduler.Callee
+			if err := json.Unmarshal(v, &c); err != nil {
+				return err
+			}
+			fn(string(k), &c)
+			return nil
+		})
+	})
+	if err != nil {
+		s.setErr(err)
+	}
+}
+
+// Len returns the number of distinct keys currently stored.
+func (s *Store) Len() int {
+	n := 0
+	err := s.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(calleesBucket).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		s.setErr(err)
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWithContextDumpsTheUnprocessedRemainderIntoTheSequentialTail(t *testing.T) {
+	s := NewScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+	sched, err := s.NewWithContext(ctx, msgs)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(sched.SequentialTail) != 2 {
+		t.Fatalf("expected both unprocessed messages dumped into the sequential tail, got %+v", sched.SequentialTail)
+	}
+}
+
+func TestNewWithBudgetReturnsAFullScheduleWithinAGenerousBudget(t *testing.T) {
+	s := NewScheduler()
+	sched, err := s.NewWithBudget([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both messages packed together, got %v", sched.Generations)
+	}
+}
+
+func TestNewWithBudgetFallsBackToTheSequentialTailOnceExpired(t *testing.T) {
+	s := NewScheduler()
+	sched, err := s.NewWithBudget([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}, 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(sched.SequentialTail) != 2 {
+		t.Fatalf("expected both messages dumped into the sequential tail, got %+v", sched.SequentialTail)
+	}
+}
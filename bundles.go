@@ -0,0 +1,52 @@
+package scheduler
+
+// AddBundle registers ids as an atomic bundle — an MEV bundle or a
+// multicall batch, say — that the next New call must schedule as a
+// contiguous, strictly ordered run rather than splitting its members
+// across concurrent generations or letting anything else interleave
+// between them. Bundle members are pulled out of ordinary generation
+// packing entirely and appended, in the given order, to
+// Schedule.SequentialTail, where messages already run one at a time in
+// order; that guarantees the bundle stays intact, at the cost of never
+// running concurrently with anything else. AddBundle is consumed by the
+// next New call and does not persist beyond it, since transaction IDs
+// aren't guaranteed unique from one block to the next.
+func (s *Scheduler) AddBundle(ids []uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bundles = append(s.bundles, append([]uint64(nil), ids...))
+}
+
+// extractBundlesLocked consumes the scheduler's pending bundles and
+// returns the messages belonging to each one, in registration order and
+// then declared-ID order within the bundle, along with the set of IDs
+// they own so newLocked can keep them out of ordinary packing. A bundle
+// member missing from msgs (not part of this batch) is skipped; the rest
+// of the bundle still runs together in order.
+func (s *Scheduler) extractBundlesLocked(msgs []*Message) ([][]*Message, map[uint64]struct{}) {
+	if len(s.bundles) == 0 {
+		return nil, nil
+	}
+	byID := make(map[uint64]*Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.ID] = m
+	}
+	bundles := s.bundles
+	s.bundles = nil
+
+	var groups [][]*Message
+	ids := make(map[uint64]struct{})
+	for _, bundle := range bundles {
+		var group []*Message
+		for _, id := range bundle {
+			if m, ok := byID[id]; ok {
+				group = append(group, m)
+				ids[id] = struct{}{}
+			}
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, ids
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import "testing"
+
+func TestAddBundleKeepsMembersContiguousAndOrderedInSequentialTail(t *testing.T) {
+	s := NewScheduler()
+	s.AddBundle([]uint64{2, 1})
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+		{ID: 3, To: "0xC", Sig: "h()", ReadSet: []string{"c"}},
+	})
+
+	if len(sched.SequentialTail) != 2 || sched.SequentialTail[0].ID != 2 || sched.SequentialTail[1].ID != 1 {
+		t.Fatalf("expected the bundle in registered order [2 1] in SequentialTail, got %+v", sched.SequentialTail)
+	}
+	for _, gen := range sched.Generations {
+		for _, m := range gen {
+			if m.ID == 1 || m.ID == 2 {
+				t.Fatalf("expected bundle members excluded from ordinary generations, found %d", m.ID)
+			}
+		}
+	}
+}
+
+func TestAddBundleSkipsMembersMissingFromTheBatch(t *testing.T) {
+	s := NewScheduler()
+	s.AddBundle([]uint64{99, 1})
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+	})
+
+	if len(sched.SequentialTail) != 1 || sched.SequentialTail[0].ID != 1 {
+		t.Fatalf("expected the present bundle member alone in SequentialTail, got %+v", sched.SequentialTail)
+	}
+}
+
+func TestBundlesAreConsumedAndDoNotLeakIntoTheNextSchedule(t *testing.T) {
+	s := NewScheduler()
+	s.AddBundle([]uint64{1, 2})
+	s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+
+	if len(s.bundles) != 0 {
+		t.Fatalf("expected bundles to be cleared after being applied, still have %v", s.bundles)
+	}
+}
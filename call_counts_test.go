@@ -0,0 +1,45 @@
+package scheduler
+
+import "testing"
+
+func TestNewPopulatesCallCounts(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xA", Sig: "f()"},
+		{ID: 3, To: "0xB", Sig: "g()"},
+	})
+
+	if len(sched.CallCounts) != len(sched.Generations) {
+		t.Fatalf("expected one CallCounts entry per generation, got %d for %d generations", len(sched.CallCounts), len(sched.Generations))
+	}
+	total := uint64(0)
+	for _, counts := range sched.CallCounts {
+		total += counts[calleeKey("0xa", "f()")]
+	}
+	if total != 2 {
+		t.Fatalf("expected f() to be counted twice across generations, got %d", total)
+	}
+}
+
+func TestAssignSpreadsAHotCalleeAcrossLanesOnAGasTie(t *testing.T) {
+	sched := &Schedule{
+		Generations: [][]*Message{{
+			{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10},
+			{ID: 2, To: "0xA", Sig: "f()", GasLimit: 10},
+			{ID: 3, To: "0xB", Sig: "g()", GasLimit: 10},
+		}},
+		CallCounts: []map[string]uint64{
+			{calleeKey("0xa", "f()"): 2, calleeKey("0xb", "g()"): 1},
+		},
+	}
+	sched.Assign(2)
+
+	lanes := make(map[uint64]int)
+	for _, m := range sched.Generations[0] {
+		lanes[m.ID] = m.Lane
+	}
+	if lanes[1] == lanes[2] {
+		t.Fatalf("expected the two f() calls to land on different lanes, got %v", lanes)
+	}
+}
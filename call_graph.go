@@ -0,0 +1,84 @@
+package scheduler
+
+// AddCallEdge records that caller internally invokes callee (e.g.
+// learned from an execution trace's nested call frames, or imported from
+// a static call-graph analysis), so conflicts recorded against callee
+// also apply to anything that calls it. caller and callee are calleeKey
+// strings, the same convention Add uses.
+func (s *Scheduler) AddCallEdge(caller, callee string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addCallEdgeLocked(caller, callee)
+}
+
+// addCallEdgeLocked is AddCallEdge's body, callable from other methods
+// that already hold s.mu so they don't deadlock re-acquiring it.
+func (s *Scheduler) addCallEdgeLocked(caller, callee string) {
+	if caller == callee {
+		return
+	}
+	if s.callGraph == nil {
+		s.callGraph = make(map[string][]string)
+	}
+	for _, existing := range s.callGraph[caller] {
+		if existing == callee {
+			return
+		}
+	}
+	s.callGraph[caller] = append(s.callGraph[caller], callee)
+}
+
+// CallEdges returns the callees caller is known to invoke directly, in
+// the order those edges were recorded.
+func (s *Scheduler) CallEdges(caller string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	edges := s.callGraph[caller]
+	out := make([]string, len(edges))
+	copy(out, edges)
+	return out
+}
+
+// reachableCalleesLocked returns root and every callee transitively
+// reachable from it by following callGraph edges, guarding against
+// cycles. Callers must hold s.mu.
+func (s *Scheduler) reachableCalleesLocked(root string) map[string]struct{} {
+	visited := map[string]struct{}{root: {}}
+	queue := []string{root}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, callee := range s.callGraph[cur] {
+			if _, ok := visited[callee]; ok {
+				continue
+			}
+			visited[callee] = struct{}{}
+			queue = append(queue, callee)
+		}
+	}
+	return visited
+}
+
+// callGraphConflictLocked reports whether a or anything a transitively
+// calls conflicts (per the direct callee-pair history) with b or
+// anything b transitively calls — propagating conflicts along the call
+// graph so a caller inherits the conflicts of what it calls into.
+// Callers must hold s.mu.
+func (s *Scheduler) callGraphConflictLocked(a, b string) bool {
+	if len(s.callGraph) == 0 {
+		return false
+	}
+	reachA := s.reachableCalleesLocked(a)
+	reachB := s.reachableCalleesLocked(b)
+	for x := range reachA {
+		for y := range reachB {
+			if x == y {
+				continue
+			}
+			if s.conflicting(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
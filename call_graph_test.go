@@ -0,0 +1,55 @@
+package scheduler
+
+import "testing"
+
+func TestCallGraphPropagatesConflictsTransitively(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xb", "inner()"), calleeKey("0xc", "h()"))
+	s.AddCallEdge(calleeKey("0xa", "f()"), calleeKey("0xb", "inner()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xC", Sig: "h()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected a() to inherit inner()'s conflict with h(), got %+v", sched.Generations)
+	}
+}
+
+func TestCallGraphWithNoConflictingCalleesDoesNotForceSeparation(t *testing.T) {
+	s := NewScheduler()
+	s.AddCallEdge(calleeKey("0xa", "f()"), calleeKey("0xb", "inner()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xC", Sig: "h()"},
+	})
+	if len(sched.Generations) != 1 {
+		t.Fatalf("expected unrelated callees to share a generation, got %+v", sched.Generations)
+	}
+}
+
+func TestCallEdgesReturnsRecordedEdgesInOrder(t *testing.T) {
+	s := NewScheduler()
+	s.AddCallEdge("a", "b")
+	s.AddCallEdge("a", "c")
+	s.AddCallEdge("a", "b")
+
+	edges := s.CallEdges("a")
+	if len(edges) != 2 || edges[0] != "b" || edges[1] != "c" {
+		t.Fatalf("expected [b c] with duplicates ignored, got %v", edges)
+	}
+}
+
+func TestCallGraphHandlesCycles(t *testing.T) {
+	s := NewScheduler()
+	s.AddCallEdge("a", "b")
+	s.AddCallEdge("b", "a")
+
+	s.mu.Lock()
+	reach := s.reachableCalleesLocked("a")
+	s.mu.Unlock()
+	if len(reach) != 2 {
+		t.Fatalf("expected a cycle to still terminate with both nodes reachable, got %v", reach)
+	}
+}
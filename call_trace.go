@@ -0,0 +1,51 @@
+package scheduler
+
+// CallFrame is one call frame from an execution trace: a contract
+// invocation, plus every further contract call it made internally,
+// nested the same way the EVM's own call stack would be. This is the
+// same (to, selector, gas) shape a debug_traceTransaction-style call
+// tracer already produces.
+type CallFrame struct {
+	To       string
+	Selector string
+	Gas      uint64
+	Calls    []CallFrame
+}
+
+// ImportCallTrace walks root and every frame nested under it, recording
+// each frame's execution via RecordExecution (populating Calls and
+// AvgGas) and a call-graph edge from each frame to its direct children
+// via AddCallEdge, so conflict propagation (see AddCallEdge) reflects
+// what the trace actually observed calling what. It returns the number
+// of frames recorded, including root.
+func (s *Scheduler) ImportCallTrace(root CallFrame) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.importCallTraceLocked(root)
+}
+
+func (s *Scheduler) importCallTraceLocked(root CallFrame) int {
+	n := 1
+	s.recordExecutionLocked(root.To, root.Selector, root.Gas)
+	caller := calleeKey(s.normalize(root.To), root.Selector)
+	for _, child := range root.Calls {
+		s.addCallEdgeLocked(caller, calleeKey(s.normalize(child.To), child.Selector))
+		n += s.importCallTraceLocked(child)
+	}
+	return n
+}
+
+// ImportCallTraces calls ImportCallTrace for every trace in traces (one
+// per historical transaction, typically) and returns the total number of
+// frames recorded across all of them, so the scheduler can be
+// bootstrapped from a batch of historical blocks instead of starting
+// cold.
+func (s *Scheduler) ImportCallTraces(traces []CallFrame) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, trace := range traces {
+		n += s.importCallTraceLocked(trace)
+	}
+	return n
+}
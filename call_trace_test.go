@@ -0,0 +1,67 @@
+package scheduler
+
+import "testing"
+
+func TestImportCallTraceRecordsExecutionAndCallEdges(t *testing.T) {
+	s := NewScheduler()
+	trace := CallFrame{
+		To:       "0xA",
+		Selector: "f()",
+		Gas:      1000,
+		Calls: []CallFrame{
+			{To: "0xB", Selector: "inner()", Gas: 400},
+		},
+	}
+
+	n := s.ImportCallTrace(trace)
+	if n != 2 {
+		t.Fatalf("expected 2 frames recorded, got %d", n)
+	}
+
+	c, ok := s.calleeDict.Get(calleeKey("0xa", "f()"))
+	if !ok || c.Calls != 1 || c.AvgGas != 1000 {
+		t.Fatalf("expected root frame recorded as an execution, got %+v ok=%v", c, ok)
+	}
+	inner, ok := s.calleeDict.Get(calleeKey("0xb", "inner()"))
+	if !ok || inner.Calls != 1 || inner.AvgGas != 400 {
+		t.Fatalf("expected nested frame recorded as an execution, got %+v ok=%v", inner, ok)
+	}
+
+	edges := s.CallEdges(calleeKey("0xa", "f()"))
+	if len(edges) != 1 || edges[0] != calleeKey("0xb", "inner()") {
+		t.Fatalf("expected a call-graph edge from f() to inner(), got %v", edges)
+	}
+}
+
+func TestImportCallTracesAccumulatesAcrossMultipleTraces(t *testing.T) {
+	s := NewScheduler()
+	n := s.ImportCallTraces([]CallFrame{
+		{To: "0xA", Selector: "f()", Gas: 100},
+		{To: "0xA", Selector: "f()", Gas: 300},
+	})
+	if n != 2 {
+		t.Fatalf("expected 2 frames recorded, got %d", n)
+	}
+	c, ok := s.calleeDict.Get(calleeKey("0xa", "f()"))
+	if !ok || c.Calls != 2 || c.AvgGas != 200 {
+		t.Fatalf("expected two executions averaged, got %+v ok=%v", c, ok)
+	}
+}
+
+func TestImportCallTracePropagatesConflictsThroughTheTrace(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xb", "inner()"), calleeKey("0xc", "h()"))
+	s.ImportCallTrace(CallFrame{
+		To:       "0xA",
+		Selector: "f()",
+		Calls:    []CallFrame{{To: "0xB", Selector: "inner()"}},
+	})
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xC", Sig: "h()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the imported call edge to keep f() and h() apart, got %+v", sched.Generations)
+	}
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/deferral"
+
+// Callee tracks scheduling-relevant statistics for a distinct (address,
+// signature) pair observed across the messages the Scheduler has planned.
+type Callee struct {
+	Address    string
+	Signature  string
+	Calls      uint64
+	Deferrable bool
+	// FullAddress holds the pre-normalization address a caller originally
+	// passed in, when that differs from Address — notably when a
+	// truncating address.Normalizer such as address.ShortNormalizer is
+	// installed via SetAddressNormalizer and Address ends up holding a
+	// short, possibly-ambiguous prefix instead of the real contract
+	// address. It is empty whenever the address passed in was already in
+	// normalized form; with the default EVM normalizer, a mixed-case input
+	// such as "0xA" is recorded here as-is.
+	FullAddress string
+	// AvgGas is the running average gas usage across every execution
+	// recorded for this callee via Scheduler.RecordExecution. It is 0
+	// until the first execution is recorded, at which point New starts
+	// preferring it over a message's own declared GasLimit for gas-budget
+	// packing and reporting.
+	AvgGas uint64
+	// DeferConfidence is the fraction (0 to 1) of this callee's examined
+	// writes that InferDeferrableFromCommutativity found to be either
+	// commutative or confined to the callee's own address, the last time
+	// that analysis ran. It is 0 until InferDeferrableFromCommutativity
+	// has examined at least one message for this callee.
+	DeferConfidence float64
+	// Defer overrides the Scheduler-wide deferral defaults (see
+	// SetDeferThreshold, SetDeferDepth) for this specific callee, set via
+	// Scheduler.SetDeferPolicy and persisted alongside the rest of the
+	// Callee record. Nil means the callee follows the Scheduler-wide
+	// defaults.
+	Defer *DeferPolicy
+}
+
+// DeferPolicy overrides deferral behavior for one callee. Any field left
+// at its zero value falls back to the Scheduler-wide default for that
+// aspect of deferral.
+type DeferPolicy struct {
+	// MinInstances overrides SetDeferThreshold: how many prior calls this
+	// callee must have accumulated before New starts deferring it. 0
+	// means "use the Scheduler-wide threshold".
+	MinInstances uint64
+	// MaxRounds overrides SetDeferDepth: how many follow-up levels this
+	// callee's deferred calls within one batch are spread across. 0
+	// means "use the Scheduler-wide depth".
+	MaxRounds int
+	// Tiebreak decides which of this callee's deferred instances within
+	// one batch are pushed to the earliest follow-up level once there
+	// are more instances than MaxRounds levels to spread them evenly
+	// across.
+	Tiebreak deferral.Tiebreak
+}
+
+func newCallee(addr, sig string) *Callee {
+	return &Callee{Address: addr, Signature: sig}
+}
+
+// newCalleeWithFullAddress is like newCallee, but additionally records
+// full as the callee's original, pre-normalization address when it
+// differs from the (possibly truncated) addr used as the lookup key.
+func newCalleeWithFullAddress(addr, full, sig string) *Callee {
+	c := newCallee(addr, sig)
+	if full != addr {
+		c.FullAddress = full
+	}
+	return c
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+// CalleeID identifies a callee the same way calleeKey does:
+// "<address>:<signature>".
+type CalleeID string
+
+// CalleeView is a read-only, point-in-time copy of a Callee, safe to hand
+// to external code without exposing the live pointer backing it.
+type CalleeView struct {
+	Address    string
+	Signature  string
+	Calls      uint64
+	Deferrable bool
+	// FullAddress mirrors Callee.FullAddress: the pre-normalization
+	// address, populated only when it differs from the (possibly
+	// truncated) Address this callee is keyed by.
+	FullAddress string
+}
+
+// Range calls fn once for every known callee, over a snapshot copied at
+// the start of the call rather than the live callee table. This lets an
+// external service stream the callee table for backup or analytics while
+// block building continues concurrently, without racing live updates or
+// holding a lock for the whole scan. Range stops early if fn returns
+// false.
+func (s *Scheduler) Range(fn func(id CalleeID, c CalleeView) bool) {
+	type snapshotEntry struct {
+		id   CalleeID
+		view CalleeView
+	}
+	s.mu.Lock()
+	snapshot := make([]snapshotEntry, 0, s.calleeDict.Len())
+	s.calleeDict.ForEach(func(key string, c *Callee) {
+		snapshot = append(snapshot, snapshotEntry{
+			id: CalleeID(key),
+			view: CalleeView{
+				Address:     c.Address,
+				Signature:   c.Signature,
+				Calls:       c.Calls,
+				Deferrable:  c.Deferrable,
+				FullAddress: c.FullAddress,
+			},
+		})
+	})
+	s.mu.Unlock()
+
+	for _, e := range snapshot {
+		if !fn(e.id, e.view) {
+			return
+		}
+	}
+}
+
+// FindByAddress returns a point-in-time view of every known callee whose
+// original, pre-normalization address was addr — the lookup a truncating
+// address.Normalizer (e.g. address.ShortNormalizer) makes necessary,
+// since Address alone may only be a short, ambiguous prefix. addr is
+// compared exactly against that original string (or against Address when
+// no FullAddress was recorded), so a callee first seen as "0xA" under the
+// default EVM normalizer is found by "0xA", not by "0xa".
+func (s *Scheduler) FindByAddress(addr string) []CalleeView {
+	var matches []CalleeView
+	s.Range(func(_ CalleeID, c CalleeView) bool {
+		full := c.FullAddress
+		if full == "" {
+			full = c.Address
+		}
+		if full == addr {
+			matches = append(matches, c)
+		}
+		return true
+	})
+	return matches
+}
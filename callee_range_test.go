@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/address"
+)
+
+func TestRangeVisitsEveryCallee(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}, {ID: 2, To: "0xb", Sig: "g()"}})
+
+	seen := make(map[CalleeID]CalleeView)
+	s.Range(func(id CalleeID, c CalleeView) bool {
+		seen[id] = c
+		return true
+	})
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 callees, got %d", len(seen))
+	}
+	if v, ok := seen[CalleeID(calleeKey("0xa", "f()"))]; !ok || v.Calls != 1 {
+		t.Fatalf("expected 0xa:f() to be visited with 1 call, got %+v ok=%v", v, ok)
+	}
+}
+
+func TestRangeStopsEarlyWhenFnReturnsFalse(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}, {ID: 2, To: "0xb", Sig: "g()"}})
+
+	visits := 0
+	s.Range(func(id CalleeID, c CalleeView) bool {
+		visits++
+		return false
+	})
+	if visits != 1 {
+		t.Fatalf("expected Range to stop after the first visit, got %d", visits)
+	}
+}
+
+func TestFindByAddressRecoversTheFullAddressBehindAShortKey(t *testing.T) {
+	s := NewScheduler()
+	s.SetAddressNormalizer(address.NewShortNormalizer(6).Normalize)
+	s.New([]*Message{{ID: 1, To: "0xabcdef123456", Sig: "f()"}})
+
+	matches := s.FindByAddress("0xabcdef123456")
+	if len(matches) != 1 || matches[0].Address != "0xabcd" {
+		t.Fatalf("expected 1 match keyed by the short address, got %+v", matches)
+	}
+}
+
+func TestFindByAddressWithoutTruncationMatchesAddressDirectly(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}})
+
+	matches := s.FindByAddress("0xa")
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %+v", matches)
+	}
+}
@@ -0,0 +1,294 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/arcology-network/scheduler/deferral"
+)
+
+// Callees is a convenience alias for a batch of Callee records, so
+// callers persisting or shipping many callees at once (e.g. the
+// persistence layer choosing between formats) can encode them as a
+// single RLP list rather than one element at a time.
+type Callees []*Callee
+
+// EncodeRLP marshals c using the RLP encoding Ethereum tooling expects,
+// as an alternative to EncodeJSON/EncodeBinary's JSON and gob formats.
+// The persistence layer selects whichever of the three suits its
+// consumer. Defer is encoded as a nested list, empty when nil, so the
+// round trip distinguishes "no override" from an explicit
+// all-zero-values DeferPolicy.
+func (c *Callee) EncodeRLP() ([]byte, error) {
+	deferItem := rlpEncodeList()
+	if c.Defer != nil {
+		deferItem = rlpEncodeList(
+			rlpEncodeUint(c.Defer.MinInstances),
+			rlpEncodeUint(uint64(c.Defer.MaxRounds)),
+			rlpEncodeUint(uint64(c.Defer.Tiebreak)),
+		)
+	}
+	return rlpEncodeList(
+		rlpEncodeBytes([]byte(c.Address)),
+		rlpEncodeBytes([]byte(c.Signature)),
+		rlpEncodeUint(c.Calls),
+		rlpEncodeBool(c.Deferrable),
+		rlpEncodeUint(c.AvgGas),
+		deferItem,
+	), nil
+}
+
+// DecodeCalleeRLP is the inverse of Callee.EncodeRLP.
+func DecodeCalleeRLP(data []byte) (*Callee, error) {
+	fields, err := rlpDecodeList(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 6 {
+		return nil, errors.New("rlp: expected 6 Callee fields")
+	}
+	address, err := rlpDecodeBytes(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	signature, err := rlpDecodeBytes(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	calls, err := rlpDecodeUint(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	deferrable, err := rlpDecodeBool(fields[3])
+	if err != nil {
+		return nil, err
+	}
+	avgGas, err := rlpDecodeUint(fields[4])
+	if err != nil {
+		return nil, err
+	}
+	deferFields, err := rlpDecodeList(fields[5])
+	if err != nil {
+		return nil, err
+	}
+	c := &Callee{
+		Address:    string(address),
+		Signature:  string(signature),
+		Calls:      calls,
+		Deferrable: deferrable,
+		AvgGas:     avgGas,
+	}
+	if len(deferFields) > 0 {
+		if len(deferFields) != 3 {
+			return nil, errors.New("rlp: expected 3 DeferPolicy fields")
+		}
+		minInstances, err := rlpDecodeUint(deferFields[0])
+		if err != nil {
+			return nil, err
+		}
+		maxRounds, err := rlpDecodeUint(deferFields[1])
+		if err != nil {
+			return nil, err
+		}
+		tiebreak, err := rlpDecodeUint(deferFields[2])
+		if err != nil {
+			return nil, err
+		}
+		c.Defer = &DeferPolicy{
+			MinInstances: minInstances,
+			MaxRounds:    int(maxRounds),
+			Tiebreak:     deferral.Tiebreak(tiebreak),
+		}
+	}
+	return c, nil
+}
+
+// EncodeRLP marshals cs as an RLP list of Callee elements.
+func (cs Callees) EncodeRLP() ([]byte, error) {
+	items := make([][]byte, len(cs))
+	for i, c := range cs {
+		item, err := c.EncodeRLP()
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return rlpEncodeList(items...), nil
+}
+
+// DecodeCalleesRLP is the inverse of Callees.EncodeRLP.
+func DecodeCalleesRLP(data []byte) (Callees, error) {
+	raw, err := rlpDecodeList(data)
+	if err != nil {
+		return nil, err
+	}
+	cs := make(Callees, len(raw))
+	for i, item := range raw {
+		c, err := DecodeCalleeRLP(item)
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = c
+	}
+	return cs, nil
+}
+
+// --- minimal RLP primitives -------------------------------------------
+//
+// The full RLP specification supports arbitrary nested byte strings and
+// lists; only that subset is implemented here, sufficient for encoding
+// Callee/Callees without pulling in an external RLP library.
+
+func rlpEncodeBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return b
+	}
+	return append(rlpLengthPrefix(0x80, 0xb7, len(b)), b...)
+}
+
+func rlpEncodeUint(v uint64) []byte {
+	return rlpEncodeBytes(rlpMinimalBytes(v))
+}
+
+func rlpEncodeBool(b bool) []byte {
+	if b {
+		return rlpEncodeUint(1)
+	}
+	return rlpEncodeUint(0)
+}
+
+func rlpEncodeList(items ...[]byte) []byte {
+	var payload []byte
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+	return append(rlpLengthPrefix(0xc0, 0xf7, len(payload)), payload...)
+}
+
+func rlpLengthPrefix(shortBase, longBase byte, n int) []byte {
+	if n <= 55 {
+		return []byte{shortBase + byte(n)}
+	}
+	lenBytes := rlpMinimalBytes(uint64(n))
+	return append([]byte{longBase + byte(len(lenBytes))}, lenBytes...)
+}
+
+func rlpMinimalBytes(v uint64) []byte {
+	if v == 0 {
+		return nil
+	}
+	var b []byte
+	for v > 0 {
+		b = append([]byte{byte(v)}, b...)
+		v >>= 8
+	}
+	return b
+}
+
+// rlpNextElement returns the full encoded bytes (prefix and payload) of
+// the first RLP element in data, and whatever follows it.
+func rlpNextElement(data []byte) (raw, rest []byte, err error) {
+	if len(data) == 0 {
+		return nil, nil, errors.New("rlp: unexpected end of input")
+	}
+	b0 := data[0]
+	var total int
+	switch {
+	case b0 < 0x80:
+		total = 1
+	case b0 < 0xb8:
+		total = 1 + int(b0-0x80)
+	case b0 < 0xc0:
+		lenLen := int(b0 - 0xb7)
+		if len(data) < 1+lenLen {
+			return nil, nil, errors.New("rlp: truncated length")
+		}
+		n := int(rlpBytesToUint(data[1 : 1+lenLen]))
+		total = 1 + lenLen + n
+	case b0 < 0xf8:
+		total = 1 + int(b0-0xc0)
+	default:
+		lenLen := int(b0 - 0xf7)
+		if len(data) < 1+lenLen {
+			return nil, nil, errors.New("rlp: truncated length")
+		}
+		n := int(rlpBytesToUint(data[1 : 1+lenLen]))
+		total = 1 + lenLen + n
+	}
+	if len(data) < total {
+		return nil, nil, errors.New("rlp: truncated input")
+	}
+	return data[:total], data[total:], nil
+}
+
+func rlpBytesToUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// rlpContent strips raw's length prefix, returning its payload and
+// whether raw is a list.
+func rlpContent(raw []byte) (content []byte, isList bool) {
+	b0 := raw[0]
+	switch {
+	case b0 < 0x80:
+		return raw[0:1], false
+	case b0 < 0xb8:
+		return raw[1:], false
+	case b0 < 0xc0:
+		lenLen := int(b0 - 0xb7)
+		return raw[1+lenLen:], false
+	case b0 < 0xf8:
+		return raw[1:], true
+	default:
+		lenLen := int(b0 - 0xf7)
+		return raw[1+lenLen:], true
+	}
+}
+
+func rlpDecodeBytes(raw []byte) ([]byte, error) {
+	content, isList := rlpContent(raw)
+	if isList {
+		return nil, errors.New("rlp: expected a string, got a list")
+	}
+	return content, nil
+}
+
+func rlpDecodeUint(raw []byte) (uint64, error) {
+	b, err := rlpDecodeBytes(raw)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) > 8 {
+		return 0, errors.New("rlp: uint64 overflow")
+	}
+	return rlpBytesToUint(b), nil
+}
+
+func rlpDecodeBool(raw []byte) (bool, error) {
+	v, err := rlpDecodeUint(raw)
+	return v != 0, err
+}
+
+func rlpDecodeList(raw []byte) ([][]byte, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("rlp: unexpected end of input")
+	}
+	content, isList := rlpContent(raw)
+	if !isList {
+		return nil, errors.New("rlp: expected a list, got a string")
+	}
+	var items [][]byte
+	rest := content
+	for len(rest) > 0 {
+		item, r, err := rlpNextElement(rest)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		rest = r
+	}
+	return items, nil
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/deferral"
+)
+
+func TestCalleeRLPRoundTrip(t *testing.T) {
+	c := &Callee{Address: "0xa", Signature: "f()", Calls: 42, Deferrable: true, AvgGas: 12345}
+
+	data, err := c.EncodeRLP()
+	if err != nil {
+		t.Fatalf("EncodeRLP: %v", err)
+	}
+	got, err := DecodeCalleeRLP(data)
+	if err != nil {
+		t.Fatalf("DecodeCalleeRLP: %v", err)
+	}
+	if *got != *c {
+		t.Fatalf("expected round trip to reproduce %+v, got %+v", c, got)
+	}
+}
+
+func TestCalleeRLPRoundTripWithDeferPolicy(t *testing.T) {
+	c := &Callee{
+		Address: "0xa", Signature: "f()",
+		Defer: &DeferPolicy{MinInstances: 5, MaxRounds: 3, Tiebreak: deferral.TiebreakHighestGas},
+	}
+
+	data, err := c.EncodeRLP()
+	if err != nil {
+		t.Fatalf("EncodeRLP: %v", err)
+	}
+	got, err := DecodeCalleeRLP(data)
+	if err != nil {
+		t.Fatalf("DecodeCalleeRLP: %v", err)
+	}
+	if got.Defer == nil || *got.Defer != *c.Defer {
+		t.Fatalf("expected Defer to round trip, got %+v", got.Defer)
+	}
+}
+
+func TestCalleesRLPRoundTrip(t *testing.T) {
+	cs := Callees{
+		{Address: "0xa", Signature: "f()", Calls: 1},
+		{Address: "0xb", Signature: "g()", Calls: 2},
+	}
+
+	data, err := cs.EncodeRLP()
+	if err != nil {
+		t.Fatalf("EncodeRLP: %v", err)
+	}
+	got, err := DecodeCalleesRLP(data)
+	if err != nil {
+		t.Fatalf("DecodeCalleesRLP: %v", err)
+	}
+	if len(got) != len(cs) || *got[0] != *cs[0] || *got[1] != *cs[1] {
+		t.Fatalf("expected round trip to reproduce %+v, got %+v", cs, got)
+	}
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import "sync"
+
+// CalleeStore abstracts calleeDict storage so it can be swapped between a
+// plain map, fast for the common case of one goroutine planning one block
+// at a time, and a concurrent-safe backend for callers that share a
+// Scheduler across goroutines. It is exported so third parties can supply
+// their own backend (e.g. an on-disk or sharded store); CalleeStoreConformance
+// documents and checks the invariants a custom implementation must uphold.
+type CalleeStore interface {
+	Get(key string) (*Callee, bool)
+	Set(key string, c *Callee)
+	ForEach(fn func(key string, c *Callee))
+	Len() int
+}
+
+type mapCalleeStore map[string]*Callee
+
+func (m mapCalleeStore) Get(key string) (*Callee, bool) {
+	c, ok := m[key]
+	return c, ok
+}
+
+func (m mapCalleeStore) Set(key string, c *Callee) { m[key] = c }
+
+func (m mapCalleeStore) ForEach(fn func(key string, c *Callee)) {
+	for k, c := range m {
+		fn(k, c)
+	}
+}
+
+func (m mapCalleeStore) Len() int { return len(m) }
+
+// syncCalleeStore is a sync.Map-backed CalleeStore, used when a Scheduler
+// is shared across goroutines (see NewConcurrentScheduler).
+type syncCalleeStore struct {
+	m sync.Map
+}
+
+func (s *syncCalleeStore) Get(key string) (*Callee, bool) {
+	v, ok := s.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Callee), true
+}
+
+func (s *syncCalleeStore) Set(key string, c *Callee) { s.m.Store(key, c) }
+
+func (s *syncCalleeStore) ForEach(fn func(key string, c *Callee)) {
+	s.m.Range(func(k, v interface{}) bool {
+		fn(k.(string), v.(*Callee))
+		return true
+	})
+}
+
+func (s *syncCalleeStore) Len() int {
+	n := 0
+	s.m.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
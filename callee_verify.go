@@ -0,0 +1,31 @@
+package scheduler
+
+// CalleeInvariantViolation describes a loaded Callee that failed a
+// sanity check.
+type CalleeInvariantViolation struct {
+	Key    string
+	Reason string
+}
+
+// VerifyCallees batch-checks every loaded callee for basic invariants —
+// non-empty address and signature, and a dict key consistent with them —
+// returning one violation per callee that fails. Intended to run once
+// after loading persisted callee data, before trusting it for live
+// scheduling.
+func (s *Scheduler) VerifyCallees() []CalleeInvariantViolation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var violations []CalleeInvariantViolation
+	s.calleeDict.ForEach(func(key string, c *Callee) {
+		if c.Address == "" {
+			violations = append(violations, CalleeInvariantViolation{Key: key, Reason: "empty address"})
+		}
+		if c.Signature == "" {
+			violations = append(violations, CalleeInvariantViolation{Key: key, Reason: "empty signature"})
+		}
+		if calleeKey(c.Address, c.Signature) != key {
+			violations = append(violations, CalleeInvariantViolation{Key: key, Reason: "dict key does not match address/signature"})
+		}
+	})
+	return violations
+}
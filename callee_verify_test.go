@@ -0,0 +1,21 @@
+package scheduler
+
+import "testing"
+
+func TestVerifyCalleesFlagsEmptyAddress(t *testing.T) {
+	s := NewScheduler()
+	s.calleeDict.Set("bad", &Callee{Signature: "f()"})
+
+	violations := s.VerifyCallees()
+	if len(violations) != 2 || violations[0].Reason != "empty address" {
+		t.Fatalf("expected an empty-address violation (plus the resulting key mismatch), got %v", violations)
+	}
+}
+
+func TestVerifyCalleesPassesForWellFormedCallees(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}})
+	if violations := s.VerifyCallees(); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
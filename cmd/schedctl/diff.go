@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcology-network/scheduler"
+)
+
+// conflictSet turns a ConflictDB's Conflicts into a set keyed by the pair
+// of callee keys, in whichever order ExportJSON already normalized them.
+func conflictSet(db scheduler.ConflictDB) map[string]struct{} {
+	set := make(map[string]struct{}, len(db.Conflicts))
+	for _, p := range db.Conflicts {
+		key := p.AddressA + ":" + p.SelectorA + "|" + p.AddressB + ":" + p.SelectorB
+		set[key] = struct{}{}
+	}
+	return set
+}
+
+// runDiff prints the callees and conflict pairs added and removed
+// between two conflict-DB snapshots, oldest first.
+func runDiff(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("diff: expected exactly two conflict-db paths")
+	}
+	oldDB, err := loadConflictDB(args[0])
+	if err != nil {
+		return err
+	}
+	newDB, err := loadConflictDB(args[1])
+	if err != nil {
+		return err
+	}
+
+	oldCallees := make(map[string]struct{}, len(oldDB.Callees))
+	for _, c := range oldDB.Callees {
+		oldCallees[c.Address+":"+c.Signature] = struct{}{}
+	}
+	newCallees := make(map[string]struct{}, len(newDB.Callees))
+	for _, c := range newDB.Callees {
+		newCallees[c.Address+":"+c.Signature] = struct{}{}
+	}
+
+	fmt.Println("callees added:")
+	for key := range newCallees {
+		if _, ok := oldCallees[key]; !ok {
+			fmt.Println("  +", key)
+		}
+	}
+	fmt.Println("callees removed:")
+	for key := range oldCallees {
+		if _, ok := newCallees[key]; !ok {
+			fmt.Println("  -", key)
+		}
+	}
+
+	oldConflicts := conflictSet(oldDB)
+	newConflicts := conflictSet(newDB)
+
+	fmt.Println("conflicts added:")
+	for key := range newConflicts {
+		if _, ok := oldConflicts[key]; !ok {
+			fmt.Println("  +", key)
+		}
+	}
+	fmt.Println("conflicts removed:")
+	for key := range oldConflicts {
+		if _, ok := newConflicts[key]; !ok {
+			fmt.Println("  -", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler"
+)
+
+func TestCalleeConflictCountsTalliesBothSides(t *testing.T) {
+	db := scheduler.ConflictDB{Conflicts: []scheduler.PairRecord{
+		{AddressA: "0xa", SelectorA: "f()", AddressB: "0xb", SelectorB: "g()"},
+		{AddressA: "0xa", SelectorA: "f()", AddressB: "0xc", SelectorB: "h()"},
+	}}
+	counts := calleeConflictCounts(db)
+	if counts["0xa:f()"] != 2 {
+		t.Fatalf("expected 0xa:f() to be counted twice, got %d", counts["0xa:f()"])
+	}
+	if counts["0xb:g()"] != 1 || counts["0xc:h()"] != 1 {
+		t.Fatalf("expected each peer counted once, got %+v", counts)
+	}
+}
+
+func TestConflictSetDetectsAddedAndRemovedPairs(t *testing.T) {
+	oldDB := scheduler.ConflictDB{Conflicts: []scheduler.PairRecord{
+		{AddressA: "0xa", SelectorA: "f()", AddressB: "0xb", SelectorB: "g()"},
+	}}
+	newDB := scheduler.ConflictDB{Conflicts: []scheduler.PairRecord{
+		{AddressA: "0xa", SelectorA: "f()", AddressB: "0xc", SelectorB: "h()"},
+	}}
+
+	oldSet := conflictSet(oldDB)
+	newSet := conflictSet(newDB)
+
+	if _, ok := oldSet["0xa:f()|0xb:g()"]; !ok {
+		t.Fatal("expected the old pair to be present in oldSet")
+	}
+	if _, ok := newSet["0xa:f()|0xb:g()"]; ok {
+		t.Fatal("expected the removed pair to be absent from newSet")
+	}
+	if _, ok := newSet["0xa:f()|0xc:h()"]; !ok {
+		t.Fatal("expected the added pair to be present in newSet")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import "fmt"
+
+// runDump prints a summary of a conflict-DB snapshot followed by the
+// full callee and conflict listing.
+func runDump(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("dump: expected exactly one conflict-db path")
+	}
+	db, err := loadConflictDB(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("callees: %d\nconflict pairs: %d\n\n", len(db.Callees), len(db.Conflicts))
+
+	fmt.Println("callees:")
+	for _, c := range db.Callees {
+		fmt.Printf("  %s:%s  calls=%d deferrable=%v avgGas=%d maintenance=%v sequential=%v\n",
+			c.Address, c.Signature, c.Calls, c.Deferrable, c.AvgGas, c.Maintenance, c.Sequential)
+	}
+
+	fmt.Println("conflicts:")
+	for _, p := range db.Conflicts {
+		fmt.Printf("  %s:%s <-> %s:%s\n", p.AddressA, p.SelectorA, p.AddressB, p.SelectorB)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Command schedctl is a small inspection tool for conflict-DB snapshots
+// (see scheduler.ExportJSON) and for previewing the schedule a batch of
+// messages would produce, without wiring up a full node.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arcology-network/scheduler"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "dump":
+		err = runDump(os.Args[2:])
+	case "top":
+		err = runTop(os.Args[2:])
+	case "diff":
+		err = runDiff(os.Args[2:])
+	case "run":
+		err = runSchedule(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "schedctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+  schedctl dump <conflict-db.json>
+  schedctl top <conflict-db.json> [n]
+  schedctl diff <old-conflict-db.json> <new-conflict-db.json>
+  schedctl run <messages.json>`)
+}
+
+func loadConflictDB(path string) (scheduler.ConflictDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return scheduler.ConflictDB{}, err
+	}
+	defer f.Close()
+	var db scheduler.ConflictDB
+	if err := json.NewDecoder(f).Decode(&db); err != nil {
+		return scheduler.ConflictDB{}, err
+	}
+	return db, nil
+}
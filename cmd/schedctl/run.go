@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/arcology-network/scheduler"
+)
+
+// runSchedule loads a JSON array of scheduler.Message from path, packs it
+// with a fresh scheduler.Scheduler, and pretty-prints the resulting
+// generations and any deferred messages.
+func runSchedule(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("run: expected exactly one messages path")
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var msgs []*scheduler.Message
+	if err := json.NewDecoder(f).Decode(&msgs); err != nil {
+		return err
+	}
+
+	sched := scheduler.NewScheduler().New(msgs)
+
+	for i, gen := range sched.Generations {
+		fmt.Printf("generation %d (gas=%d):\n", i, sched.GenerationGas[i])
+		for _, m := range gen {
+			fmt.Printf("  id=%d to=%s sig=%s lane=%d\n", m.ID, m.To, m.Sig, m.Lane)
+		}
+	}
+	if len(sched.Deferred) > 0 {
+		fmt.Println("deferred:")
+		for _, m := range sched.Deferred {
+			fmt.Printf("  id=%d to=%s sig=%s\n", m.ID, m.To, m.Sig)
+		}
+	}
+	return nil
+}
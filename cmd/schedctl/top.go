@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/arcology-network/scheduler"
+)
+
+// calleeConflictCounts tallies, for every callee named in db.Conflicts,
+// how many distinct peers it conflicts with.
+func calleeConflictCounts(db scheduler.ConflictDB) map[string]int {
+	counts := make(map[string]int)
+	for _, p := range db.Conflicts {
+		counts[p.AddressA+":"+p.SelectorA]++
+		counts[p.AddressB+":"+p.SelectorB]++
+	}
+	return counts
+}
+
+// runTop prints the n callees (default 10) involved in the most conflict
+// pairs, busiest first.
+func runTop(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("top: expected a conflict-db path and an optional count")
+	}
+	n := 10
+	if len(args) == 2 {
+		v, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("top: invalid count %q: %w", args[1], err)
+		}
+		n = v
+	}
+
+	db, err := loadConflictDB(args[0])
+	if err != nil {
+		return err
+	}
+
+	counts := calleeConflictCounts(db)
+	callees := make([]string, 0, len(counts))
+	for k := range counts {
+		callees = append(callees, k)
+	}
+	sort.Slice(callees, func(i, j int) bool {
+		if counts[callees[i]] != counts[callees[j]] {
+			return counts[callees[i]] > counts[callees[j]]
+		}
+		return callees[i] < callees[j]
+	})
+	if n < len(callees) {
+		callees = callees[:n]
+	}
+
+	for _, key := range callees {
+		fmt.Printf("%-6d %s\n", counts[key], key)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+// compactGenerations merges each generation into the earliest earlier
+// generation it can join without conflict, shrinking the number of
+// generations (and therefore execution barriers) left behind by a pass
+// like deferral that can fragment a schedule more than necessary. It
+// respects SetMaxGenerationSize the same way New does, and never merges
+// a maintenance-class generation (see SetMaintenance) with anything
+// else, preserving New's guarantee that it stays reserved and last.
+func (s *Scheduler) compactGenerations(sched *Schedule) *Schedule {
+	compacted := &Schedule{
+		Deferred:       sched.Deferred,
+		DeferredLevels: sched.DeferredLevels,
+		RollbackHints:  sched.RollbackHints,
+		SequentialTail: sched.SequentialTail,
+	}
+
+	for i, gen := range sched.Generations {
+		merged := false
+		if !s.isMaintenanceGeneration(gen) {
+			for j := range compacted.Generations {
+				if s.isMaintenanceGeneration(compacted.Generations[j]) {
+					continue
+				}
+				if s.generationsCompatible(compacted.Generations[j], gen) {
+					compacted.Generations[j] = append(compacted.Generations[j], gen...)
+					compacted.GenerationGas[j] += sched.GenerationGas[i]
+					merged = true
+					break
+				}
+			}
+		}
+		if !merged {
+			compacted.Generations = append(compacted.Generations, append([]*Message{}, gen...))
+			compacted.GenerationGas = append(compacted.GenerationGas, sched.GenerationGas[i])
+		}
+	}
+
+	compacted.CallCounts = s.computeCallCounts(compacted.Generations)
+	return compacted
+}
+
+// generationsCompatible reports whether every message in b can join a
+// without conflict, and the combined size stays within maxGenSize.
+func (s *Scheduler) generationsCompatible(a, b []*Message) bool {
+	if s.maxGenSize > 0 && len(a)+len(b) > s.maxGenSize {
+		return false
+	}
+	if s.maxGenGas > 0 && s.estimatedGenerationGas(a)+s.estimatedGenerationGas(b) > s.maxGenGas {
+		return false
+	}
+	for _, m := range b {
+		if !s.fitsGeneration(m, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMaintenanceGeneration reports whether gen consists of
+// maintenance-class messages, i.e. is the reserved final generation New
+// produces when any maintenance callee is present.
+func (s *Scheduler) isMaintenanceGeneration(gen []*Message) bool {
+	for _, m := range gen {
+		if s.isMaintenance(m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import "testing"
+
+func TestCompactGenerationsMergesConflictFreeGenerations(t *testing.T) {
+	s := NewScheduler()
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10}},
+			{{ID: 2, To: "0xB", Sig: "g()", GasLimit: 20}},
+		},
+		GenerationGas: []uint64{10, 20},
+	}
+
+	compacted := s.compactGenerations(sched)
+	if len(compacted.Generations) != 1 || len(compacted.Generations[0]) != 2 {
+		t.Fatalf("expected the two conflict-free generations to compact into one, got %v", compacted.Generations)
+	}
+	if compacted.GenerationGas[0] != 30 {
+		t.Fatalf("expected merged generation gas of 30, got %v", compacted.GenerationGas)
+	}
+}
+
+func TestCompactGenerationsLeavesConflictingGenerationsSeparate(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()"}},
+			{{ID: 2, To: "0xB", Sig: "g()"}},
+		},
+		GenerationGas: []uint64{0, 0},
+	}
+
+	compacted := s.compactGenerations(sched)
+	if len(compacted.Generations) != 2 {
+		t.Fatalf("expected conflicting callees to remain in separate generations, got %v", compacted.Generations)
+	}
+}
+
+func TestCompactGenerationsRespectsMaxGenerationSize(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerationSize(1)
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()"}},
+			{{ID: 2, To: "0xB", Sig: "g()"}},
+		},
+		GenerationGas: []uint64{0, 0},
+	}
+
+	compacted := s.compactGenerations(sched)
+	if len(compacted.Generations) != 2 {
+		t.Fatalf("expected max generation size to block the merge, got %v", compacted.Generations)
+	}
+}
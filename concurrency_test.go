@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentSchedulerIsSafeForConcurrentNewAndAdd exercises the
+// guarantee that a NewConcurrentScheduler can be shared across goroutines
+// proposing separate blocks: concurrent New and Add calls must not race,
+// even though they touch the same conflict graph and callee dict.
+func TestConcurrentSchedulerIsSafeForConcurrentNewAndAdd(t *testing.T) {
+	s := NewConcurrentScheduler()
+
+	const proposers = 8
+	var wg sync.WaitGroup
+	for p := 0; p < proposers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			to := fmt.Sprintf("0x%d", p)
+			sig := "f()"
+			s.Add(calleeKey(to, sig), calleeKey(to, sig+"g"))
+			sched := s.New([]*Message{
+				{ID: uint64(p*2 + 1), To: to, Sig: sig},
+				{ID: uint64(p*2 + 2), To: to, Sig: sig + "g"},
+			})
+			if len(sched.Generations) == 0 {
+				t.Errorf("proposer %d: expected at least one generation", p)
+			}
+		}(p)
+	}
+	wg.Wait()
+}
+
+// TestConcurrentSchedulerReportIsSafeDuringLiveUpdates exercises reading
+// WarmUpReport and ConflictChain while New and Add keep running
+// concurrently, mirroring a monitoring goroutine polling a live proposer.
+func TestConcurrentSchedulerReportIsSafeDuringLiveUpdates(t *testing.T) {
+	s := NewConcurrentScheduler()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			to := fmt.Sprintf("0x%d", i)
+			s.Add(calleeKey(to, "f()"), calleeKey(to, "g()"))
+			s.New([]*Message{{ID: uint64(i), To: to, Sig: "f()"}})
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		s.WarmUpReport()
+		s.ConflictChain(calleeKey("0x0", "f()"))
+	}
+	close(stop)
+	wg.Wait()
+}
+
+// TestConcurrentSchedulerFilterStatsIsSafeDuringLiveUpdates exercises
+// reading FilterStats and adjusting SetFilterCaptureLimit while Add keeps
+// running concurrently, since Add is what mutates the underlying
+// FilterStats via addLocked.
+func TestConcurrentSchedulerFilterStatsIsSafeDuringLiveUpdates(t *testing.T) {
+	s := NewConcurrentScheduler()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			to := fmt.Sprintf("0x%d", i)
+			s.Add(calleeKey(to, "f()"), calleeKey(to, "f()"))
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		s.FilterStats()
+		s.SetFilterCaptureLimit(i%10 + 1)
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import "testing"
+
+func TestConflictBelowConfidenceThresholdIsIgnored(t *testing.T) {
+	s := NewScheduler()
+	s.SetConflictConfidence(2)
+	s.Add("a", "b")
+
+	if s.conflicting("a", "b") {
+		t.Fatalf("expected a single observation to fall short of a confidence threshold of 2")
+	}
+
+	s.Add("a", "b")
+	if !s.conflicting("a", "b") {
+		t.Fatalf("expected a second observation to meet the confidence threshold")
+	}
+}
+
+func TestZeroConfidenceTreatsASingleObservationAsConflicting(t *testing.T) {
+	s := NewScheduler()
+	s.Add("a", "b")
+
+	if !s.conflicting("a", "b") {
+		t.Fatalf("expected the default (zero) confidence threshold to trust a single observation")
+	}
+}
+
+func TestConflictObservationsCountsRepeatedAdds(t *testing.T) {
+	s := NewScheduler()
+	s.Add("a", "b")
+	s.Add("b", "a")
+	s.Add("a", "b")
+
+	if got := s.ConflictObservations("a", "b"); got != 3 {
+		t.Fatalf("expected 3 recorded observations, got %d", got)
+	}
+	if got := s.ConflictObservations("b", "a"); got != 3 {
+		t.Fatalf("expected ConflictObservations to be order-independent, got %d", got)
+	}
+}
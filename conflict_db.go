@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CalleeRecord is one callee's learned statistics and flags in the
+// portable conflict-DB schema ExportJSON/ImportJSON round-trip. It
+// carries the callee's address and signature as separate fields rather
+// than an opaque callee key, so the JSON stays readable and diffable
+// when checked into a configuration repo. Address is the normalized
+// Callee.Address (the truncated form under a truncating normalizer, not
+// Callee.FullAddress), and Signature is written exactly as stored; it is
+// not converted to a 0x selector.
+type CalleeRecord struct {
+	Address     string
+	Signature   string
+	Calls       uint64
+	Deferrable  bool
+	AvgGas      uint64
+	Maintenance bool
+	Sequential  bool
+}
+
+// ConflictDB is the stable, human-readable schema ExportJSON writes and
+// ImportJSON reads: every learned callee alongside the conflict pairs
+// between them. Conflicts reuses PairRecord, the same shape
+// ImportPairsFile already accepts, so a conflict DB exported here can
+// also be fed to ImportPairsFile (ignoring the Callees section) and vice
+// versa.
+type ConflictDB struct {
+	Callees   []CalleeRecord
+	Conflicts []PairRecord
+}
+
+// conflictDBMagic identifies the JSON on disk as a versioned ConflictDB
+// envelope rather than the legacy, unversioned encoding ExportJSON wrote
+// before conflictDBVersion existed. A file missing this field entirely
+// (any legacy export) is treated as version 0 and migrated in place by
+// migrateConflictDB.
+const conflictDBMagic = "arcology-conflict-db"
+
+// conflictDBVersion is the format version ExportJSON currently writes.
+// Bump it, and add a case to migrateConflictDB, whenever a change to
+// CalleeRecord or PairRecord would otherwise make an older export
+// misread under the new schema.
+const conflictDBVersion = 1
+
+// conflictDBEnvelope is the schema actually written to and read from
+// disk: conflictDBMagic and a version number wrapped around the portable
+// ConflictDB payload, so a future format change can be recognized and
+// migrated instead of silently misparsed. Embedding ConflictDB flattens
+// Callees/Conflicts into the same JSON object as Magic/Version, so a
+// legacy file (which has Callees/Conflicts but no Magic/Version) decodes
+// into this struct just fine, with Magic left as the empty string.
+type conflictDBEnvelope struct {
+	Magic   string `json:"magic,omitempty"`
+	Version int    `json:"version,omitempty"`
+	ConflictDB
+}
+
+// migrateConflictDB upgrades db, written under the given version, to
+// conflictDBVersion. version 0 covers every export ExportJSON wrote
+// before this envelope existed, whose payload already matches the
+// current ConflictDB shape, so there is nothing to translate yet; this
+// is the seam later CalleeRecord/PairRecord changes should extend.
+func migrateConflictDB(db ConflictDB, version int) ConflictDB {
+	return db
+}
+
+// splitCalleeKey recovers the (address, signature) pair a calleeKey was
+// built from. It assumes the address itself never contains ":", which
+// holds for every address form calleeKey is used with in this package.
+func splitCalleeKey(key string) (address, signature string) {
+	address, signature, _ = strings.Cut(key, ":")
+	return address, signature
+}
+
+// ExportJSON writes the Scheduler's learned callee statistics, flags,
+// and conflict graph to w as a versioned ConflictDB envelope (see
+// conflictDBMagic, conflictDBVersion), so it can be reviewed, checked
+// into a configuration repo, or handed to another operator's Scheduler
+// via ImportJSON. Callees and conflict pairs are both emitted in sorted
+// order, so re-exporting an unchanged Scheduler produces byte-identical
+// output.
+func (s *Scheduler) ExportJSON(w io.Writer) error {
+	s.mu.Lock()
+	db := s.exportLocked()
+	s.mu.Unlock()
+
+	env := conflictDBEnvelope{Magic: conflictDBMagic, Version: conflictDBVersion, ConflictDB: db}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(env)
+}
+
+// exportLocked is ExportJSON's body, minus the JSON encoding, callable
+// from other methods that already hold s.mu.
+func (s *Scheduler) exportLocked() ConflictDB {
+	db := ConflictDB{}
+	s.calleeDict.ForEach(func(key string, c *Callee) {
+		_, isMaintenance := s.maintenance[key]
+		_, isSequential := s.sequential[key]
+		db.Callees = append(db.Callees, CalleeRecord{
+			Address:     c.Address,
+			Signature:   c.Signature,
+			Calls:       c.Calls,
+			Deferrable:  c.Deferrable,
+			AvgGas:      c.AvgGas,
+			Maintenance: isMaintenance,
+			Sequential:  isSequential,
+		})
+	})
+	sort.Slice(db.Callees, func(i, j int) bool {
+		if db.Callees[i].Address != db.Callees[j].Address {
+			return db.Callees[i].Address < db.Callees[j].Address
+		}
+		return db.Callees[i].Signature < db.Callees[j].Signature
+	})
+
+	seen := make(map[string]struct{})
+	for a, peers := range s.conflicts {
+		for b := range peers {
+			pair := pairKey(a, b)
+			if _, ok := seen[pair]; ok {
+				continue
+			}
+			seen[pair] = struct{}{}
+			addrA, sigA := splitCalleeKey(a)
+			addrB, sigB := splitCalleeKey(b)
+			if a > b {
+				addrA, sigA, addrB, sigB = addrB, sigB, addrA, sigA
+			}
+			db.Conflicts = append(db.Conflicts, PairRecord{
+				AddressA: addrA, SelectorA: sigA,
+				AddressB: addrB, SelectorB: sigB,
+			})
+		}
+	}
+	sort.Slice(db.Conflicts, func(i, j int) bool {
+		p, q := db.Conflicts[i], db.Conflicts[j]
+		if p.AddressA != q.AddressA {
+			return p.AddressA < q.AddressA
+		}
+		if p.SelectorA != q.SelectorA {
+			return p.SelectorA < q.SelectorA
+		}
+		if p.AddressB != q.AddressB {
+			return p.AddressB < q.AddressB
+		}
+		return p.SelectorB < q.SelectorB
+	})
+
+	return db
+}
+
+// ImportJSON reads a ConflictDB previously written by ExportJSON (or
+// hand-authored in the same schema) from r and merges it into the
+// Scheduler: each callee's Calls, Deferrable, AvgGas, Maintenance, and
+// Sequential are restored, and every conflict pair is recorded via Add.
+// A legacy export with no magic/version envelope (anything ExportJSON
+// wrote before conflictDBVersion existed) is recognized as version 0 and
+// migrated transparently, so callers never need to know which format a
+// file on disk was written in. It returns the number of callees and
+// conflict pairs imported combined.
+func (s *Scheduler) ImportJSON(r io.Reader) (int, error) {
+	var env conflictDBEnvelope
+	if err := json.NewDecoder(r).Decode(&env); err != nil {
+		return 0, err
+	}
+	version := env.Version
+	if env.Magic == "" {
+		version = 0
+	}
+	db := migrateConflictDB(env.ConflictDB, version)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.importLocked(db), nil
+}
+
+// importLocked is ImportJSON's body, minus the JSON decoding, callable
+// from other methods that already hold s.mu.
+func (s *Scheduler) importLocked(db ConflictDB) int {
+	for _, cr := range db.Callees {
+		c := s.calleeFor(cr.Address, cr.Signature)
+		c.Calls = cr.Calls
+		c.Deferrable = cr.Deferrable
+		c.AvgGas = cr.AvgGas
+		key := calleeKey(s.normalize(cr.Address), cr.Signature)
+		s.calleeDict.Set(key, c)
+
+		if cr.Maintenance {
+			if s.maintenance == nil {
+				s.maintenance = make(map[string]struct{})
+			}
+			s.maintenance[key] = struct{}{}
+		} else {
+			delete(s.maintenance, key)
+		}
+
+		if cr.Sequential {
+			if s.sequential == nil {
+				s.sequential = make(map[string]struct{})
+			}
+			s.sequential[key] = struct{}{}
+		} else {
+			delete(s.sequential, key)
+		}
+	}
+
+	for _, pr := range db.Conflicts {
+		a := calleeKey(s.normalize(pr.AddressA), pr.SelectorA)
+		b := calleeKey(s.normalize(pr.AddressB), pr.SelectorB)
+		s.addLocked(a, b)
+	}
+
+	return len(db.Callees) + len(db.Conflicts)
+}
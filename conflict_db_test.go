@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportJSONThenImportJSONRoundTripsFlagsAndConflicts(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xA", "f()", true)
+	s.SetMaintenance("0xA", "f()", true)
+	s.calleeFor("0xA", "f()").Calls = 7
+	s.calleeFor("0xA", "f()").AvgGas = 500
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	var buf bytes.Buffer
+	if err := s.ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+
+	dst := NewScheduler()
+	n, err := dst.ImportJSON(&buf)
+	if err != nil {
+		t.Fatalf("ImportJSON: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 records imported (1 callee + 1 conflict pair), got %d", n)
+	}
+
+	c, ok := dst.calleeDict.Get(calleeKey("0xa", "f()"))
+	if !ok {
+		t.Fatal("expected callee 0xA:f() to be imported")
+	}
+	if c.Calls != 7 || c.AvgGas != 500 || !c.Deferrable {
+		t.Fatalf("expected imported callee stats to match the export, got %+v", c)
+	}
+	if !dst.isMaintenance(&Message{To: "0xA", Sig: "f()"}) {
+		t.Fatal("expected the Maintenance flag to survive the round trip")
+	}
+	if !dst.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the conflict pair to survive the round trip")
+	}
+}
+
+func TestExportJSONIsDeterministicAcrossRuns(t *testing.T) {
+	s := NewScheduler()
+	s.calleeFor("0xB", "g()")
+	s.calleeFor("0xA", "f()")
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	var first, second bytes.Buffer
+	if err := s.ExportJSON(&first); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	if err := s.ExportJSON(&second); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	if first.String() != second.String() {
+		t.Fatal("expected two exports of an unchanged scheduler to be byte-identical")
+	}
+	if !strings.Contains(first.String(), `"Address": "0xa"`) {
+		t.Fatalf("expected exported JSON to use full addresses, got %s", first.String())
+	}
+}
+
+func TestImportJSONMergesIntoExistingConflicts(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xc", "h()"))
+
+	db := ConflictDB{Conflicts: []PairRecord{
+		{AddressA: "0xA", SelectorA: "f()", AddressB: "0xB", SelectorB: "g()"},
+	}}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(db); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	if _, err := s.ImportJSON(&buf); err != nil {
+		t.Fatalf("ImportJSON: %v", err)
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xc", "h()")) {
+		t.Fatal("expected the pre-existing conflict to survive the merge")
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the imported conflict to be recorded")
+	}
+}
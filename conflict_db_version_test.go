@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportJSONWritesAMagicAndVersion(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	var buf bytes.Buffer
+	if err := s.ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), conflictDBMagic) {
+		t.Fatalf("expected exported JSON to carry the conflict DB magic, got %s", buf.String())
+	}
+}
+
+func TestImportJSONLoadsALegacyUnversionedExportTransparently(t *testing.T) {
+	legacy := `{
+  "Callees": [
+    {"Address": "0xa", "Signature": "f()", "Calls": 3, "Deferrable": true, "AvgGas": 0, "Maintenance": false, "Sequential": false}
+  ],
+  "Conflicts": [
+    {"AddressA": "0xa", "SelectorA": "f()", "AddressB": "0xb", "SelectorB": "g()"}
+  ]
+}`
+	s := NewScheduler()
+	n, err := s.ImportJSON(strings.NewReader(legacy))
+	if err != nil {
+		t.Fatalf("ImportJSON: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 records imported, got %d", n)
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the legacy conflict pair to be imported")
+	}
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import "testing"
+
+// CalleeStoreConformance runs a shared suite of invariant checks against
+// a CalleeStore implementation returned by newStore, so a third-party
+// storage backend can prove it upholds the same contract mapCalleeStore
+// and syncCalleeStore do, before a Scheduler is trusted to run on it. Call
+// it from the backend's own test package:
+//
+//	func TestConformance(t *testing.T) {
+//		scheduler.CalleeStoreConformance(t, func() scheduler.CalleeStore { return NewMyStore() })
+//	}
+func CalleeStoreConformance(t *testing.T, newStore func() CalleeStore) {
+	t.Run("GetMissingReturnsFalse", func(t *testing.T) {
+		s := newStore()
+		if _, ok := s.Get("missing"); ok {
+			t.Fatal("expected Get on an empty store to report not found")
+		}
+	})
+
+	t.Run("SetThenGetRoundTrips", func(t *testing.T) {
+		s := newStore()
+		c := newCallee("0xa", "f()")
+		s.Set("k", c)
+		got, ok := s.Get("k")
+		if !ok || got != c {
+			t.Fatalf("expected Set then Get to round-trip the same *Callee, got %+v, %v", got, ok)
+		}
+	})
+
+	t.Run("SetOverwritesExistingKey", func(t *testing.T) {
+		s := newStore()
+		s.Set("k", newCallee("0xa", "f()"))
+		second := newCallee("0xb", "g()")
+		s.Set("k", second)
+		got, ok := s.Get("k")
+		if !ok || got != second {
+			t.Fatalf("expected the second Set to overwrite the first, got %+v, %v", got, ok)
+		}
+	})
+
+	t.Run("LenTracksDistinctKeys", func(t *testing.T) {
+		s := newStore()
+		s.Set("a", newCallee("0xa", "f()"))
+		s.Set("b", newCallee("0xb", "g()"))
+		s.Set("a", newCallee("0xa", "f()")) // overwrite, not a new key
+		if n := s.Len(); n != 2 {
+			t.Fatalf("expected Len to count 2 distinct keys, got %d", n)
+		}
+	})
+
+	t.Run("ForEachVisitsEveryEntryExactlyOnce", func(t *testing.T) {
+		s := newStore()
+		s.Set("a", newCallee("0xa", "f()"))
+		s.Set("b", newCallee("0xb", "g()"))
+
+		visits := make(map[string]int)
+		s.ForEach(func(key string, c *Callee) {
+			visits[key]++
+		})
+		if len(visits) != 2 || visits["a"] != 1 || visits["b"] != 1 {
+			t.Fatalf("expected ForEach to visit each of 2 keys exactly once, got %v", visits)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package scheduler
+
+import "testing"
+
+func TestMapCalleeStoreConformsToCalleeStore(t *testing.T) {
+	CalleeStoreConformance(t, func() CalleeStore { return make(mapCalleeStore) })
+}
+
+func TestSyncCalleeStoreConformsToCalleeStore(t *testing.T) {
+	CalleeStoreConformance(t, func() CalleeStore { return &syncCalleeStore{} })
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+// AddConstraint registers a mandatory ordering between two transactions
+// that appear in the same New call: whatever generation the beforeTxID
+// message lands in, the afterTxID message must land in a later one, even
+// if conflict detection alone would have let them share a generation or
+// would have ordered them the other way (e.g. an oracle price update
+// that must apply before the trades that read it). Every registered
+// constraint is consumed by the next New call, whether or not both IDs
+// turn up in that batch, and doesn't persist beyond it, since
+// transaction IDs aren't guaranteed unique from one block to the next.
+func (s *Scheduler) AddConstraint(beforeTxID, afterTxID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addConstraintLocked(beforeTxID, afterTxID)
+}
+
+// addConstraintLocked is AddConstraint's body, callable from other
+// methods that already hold s.mu so they don't deadlock re-acquiring it.
+func (s *Scheduler) addConstraintLocked(beforeTxID, afterTxID uint64) {
+	if s.constraints == nil {
+		s.constraints = make(map[uint64][]uint64)
+	}
+	s.constraints[afterTxID] = append(s.constraints[afterTxID], beforeTxID)
+}
+
+// AddConstraints is AddConstraint's bulk form, taking a batch of
+// [beforeTxID, afterTxID] pairs.
+func (s *Scheduler) AddConstraints(pairs [][2]uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range pairs {
+		s.addConstraintLocked(p[0], p[1])
+	}
+}
+
+// enforceConstraintOrder moves any message that landed in the same
+// generation as, or an earlier one than, a message it's registered to
+// follow (via AddConstraint) into a fresh generation after it, the same
+// way enforceNonceOrder fixes up same-sender ordering. Iterating a
+// bounded number of passes lets a chain of constraints settle (an "after"
+// message pushed forward may itself be a "before" for something else)
+// while still terminating if the caller registered a cycle, in which
+// case whatever ordering was reachable is kept and the rest left as
+// packed.
+func (s *Scheduler) enforceConstraintOrder(gens [][]*Message, gas []uint64) ([][]*Message, []uint64) {
+	if len(s.constraints) == 0 {
+		return gens, gas
+	}
+	constraints := s.constraints
+	s.constraints = nil
+
+	index := make(map[uint64]int)
+	byID := make(map[uint64]*Message)
+	for gi, gen := range gens {
+		for _, m := range gen {
+			index[m.ID] = gi
+			byID[m.ID] = m
+		}
+	}
+
+	for pass, changed := 0, true; changed && pass <= len(index); pass++ {
+		changed = false
+		for after, befores := range constraints {
+			m, ok := byID[after]
+			if !ok {
+				continue
+			}
+			for _, before := range befores {
+				beforeIdx, ok := index[before]
+				if !ok || index[m.ID] > beforeIdx {
+					continue
+				}
+				gens[index[m.ID]] = removeMessage(gens[index[m.ID]], m)
+				gas[index[m.ID]] -= s.estimatedGas(m)
+				gens = append(gens, []*Message{m})
+				gas = append(gas, s.estimatedGas(m))
+				index[m.ID] = len(gens) - 1
+				changed = true
+			}
+		}
+	}
+	return dropEmptyGenerations(gens, gas)
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import "testing"
+
+func TestAddConstraintForcesTheAfterMessageIntoALaterGeneration(t *testing.T) {
+	s := NewScheduler()
+	s.AddConstraint(1, 2)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+	})
+
+	genOf := func(id uint64) int {
+		for i, gen := range sched.Generations {
+			for _, m := range gen {
+				if m.ID == id {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	if genOf(1) >= genOf(2) {
+		t.Fatalf("expected message 1 in an earlier generation than message 2, got %d and %d", genOf(1), genOf(2))
+	}
+}
+
+func TestAddConstraintsBulkAppliesEveryPair(t *testing.T) {
+	s := NewScheduler()
+	s.AddConstraints([][2]uint64{{1, 2}, {2, 3}})
+
+	sched := s.New([]*Message{
+		{ID: 3, To: "0xC", Sig: "h()", ReadSet: []string{"c"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+	})
+
+	genOf := func(id uint64) int {
+		for i, gen := range sched.Generations {
+			for _, m := range gen {
+				if m.ID == id {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	if !(genOf(1) < genOf(2) && genOf(2) < genOf(3)) {
+		t.Fatalf("expected 1 < 2 < 3 by generation, got %d, %d, %d", genOf(1), genOf(2), genOf(3))
+	}
+}
+
+func TestConstraintsAreConsumedAndDoNotLeakIntoTheNextSchedule(t *testing.T) {
+	s := NewScheduler()
+	s.AddConstraint(1, 2)
+	s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+
+	if len(s.constraints) != 0 {
+		t.Fatalf("expected constraints to be cleared after being applied, still have %v", s.constraints)
+	}
+}
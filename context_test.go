@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewWithContextReturnsFullScheduleWhenNotCanceled(t *testing.T) {
+	s := NewScheduler()
+	sched, err := s.NewWithContext(context.Background(), []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both messages packed together, got %v", sched.Generations)
+	}
+}
+
+func TestNewWithContextAbortsOnCanceledContext(t *testing.T) {
+	s := NewScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sched, err := s.NewWithContext(ctx, []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if sched == nil {
+		t.Fatal("expected a non-nil partial schedule")
+	}
+}
+
+func TestNewWithContextPlacesNoMessageOnceCanceled(t *testing.T) {
+	s := NewScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgs := make([]*Message, 0, 50)
+	for i := 0; i < 50; i++ {
+		msgs = append(msgs, &Message{ID: uint64(i + 1), To: "0xA", Sig: "f()"})
+	}
+	sched, err := s.NewWithContext(ctx, msgs)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(sched.Generations) != 0 {
+		t.Fatalf("expected packing to stop before placing any message, got %v", sched.Generations)
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/arbitrator"
+
+// CrossCheck runs a fresh arbitrator over each generation of sched
+// independently and returns every conflict found within a generation —
+// i.e. every place the optimistic schedule believed two messages could
+// run concurrently but live arbitration disagrees. Conflicts across
+// generations are expected (later generations may legitimately touch
+// paths earlier ones wrote) and are not reported. Generations are
+// independent of each other, so if a worker pool was installed via
+// SetWorkerPool, they're arbitrated on it concurrently instead of one at
+// a time.
+func (s *Scheduler) CrossCheck(sched *Schedule) []arbitrator.Conflict {
+	results := make([][]arbitrator.Conflict, len(sched.Generations))
+	check := func(i int) {
+		gen := sched.Generations[i]
+		a := arbitrator.New()
+		accs := make([]arbitrator.Access, 0, len(gen))
+		for _, m := range gen {
+			accs = append(accs, arbitrator.Access{
+				ID:                m.ID,
+				ReadSet:           m.ReadSet,
+				WriteSet:          m.WriteSet,
+				CommutativeWrites: m.CoinbaseWrites,
+			})
+		}
+		conflicts, _ := a.Detect(accs)
+		results[i] = conflicts
+	}
+
+	s.mu.Lock()
+	pool := s.pool
+	s.mu.Unlock()
+
+	if pool != nil {
+		pool.ForEach(len(sched.Generations), check)
+	} else {
+		for i := range sched.Generations {
+			check(i)
+		}
+	}
+
+	var violations []arbitrator.Conflict
+	for _, r := range results {
+		violations = append(violations, r...)
+	}
+	return violations
+}
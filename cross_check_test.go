@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/workerpool"
+)
+
+func TestCrossCheckFindsUndetectedConflict(t *testing.T) {
+	s := NewScheduler()
+	// A hand-built schedule puts these two messages in one generation,
+	// but they actually write the same path.
+	sched := &Schedule{Generations: [][]*Message{{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"p"}},
+		{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"p"}},
+	}}}
+
+	violations := s.CrossCheck(sched)
+	if len(violations) != 1 {
+		t.Fatalf("expected cross-check to catch the missed conflict, got %v", violations)
+	}
+}
+
+func TestCrossCheckIgnoresCrossGenerationReuse(t *testing.T) {
+	s := NewScheduler()
+	sched := &Schedule{Generations: [][]*Message{
+		{{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"p"}}},
+		{{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"p"}}},
+	}}
+
+	if violations := s.CrossCheck(sched); len(violations) != 0 {
+		t.Fatalf("expected no violations across generations, got %v", violations)
+	}
+}
+
+func TestCrossCheckUsesInstalledWorkerPool(t *testing.T) {
+	s := NewScheduler()
+	pool := workerpool.New(2)
+	defer pool.Close()
+	s.SetWorkerPool(pool)
+
+	sched := &Schedule{Generations: [][]*Message{
+		{{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"p"}}},
+		{{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"p2"}}},
+	}}
+
+	if violations := s.CrossCheck(sched); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+// DAG returns, for every message ID in sched.Generations, the IDs of the
+// messages that must finish first: those in earlier generations it
+// actually conflicts with, per the same rules New used to decide the
+// generation matrix in the first place. A message that only landed in a
+// later generation because an earlier one was already full (rather than
+// because of a real conflict) comes back with no edge to that
+// generation's messages, so an executor able to schedule by dependency
+// rather than by whole-generation barrier can start it as soon as its
+// true prerequisites are done. It doesn't cover SequentialTail or
+// Deferred/DeferredLevels, which already run in strict isolation or
+// afterward and have no finer-grained structure to expose.
+func (s *Scheduler) DAG(sched *Schedule) map[uint64][]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dag := make(map[uint64][]uint64)
+	var earlier [][]*Message
+	for _, gen := range sched.Generations {
+		for _, m := range gen {
+			var prereqs []uint64
+			for _, earlierGen := range earlier {
+				for _, other := range earlierGen {
+					if s.dagConflict(other, m) {
+						prereqs = append(prereqs, other.ID)
+					}
+				}
+			}
+			dag[m.ID] = prereqs
+		}
+		earlier = append(earlier, gen)
+	}
+	return dag
+}
+
+// dagConflict mirrors messagesConflict's verdict for a pair of messages
+// without its side effects (recording an optimistic clearing decision
+// and a rollback hint), since DAG re-evaluates pairs New has already
+// scheduled once and mustn't record them a second time.
+func (s *Scheduler) dagConflict(a, b *Message) bool {
+	if a.From != "" && a.From == b.From {
+		return true
+	}
+	if s.isSequential(s.messageKey(a)) || s.isSequential(s.messageKey(b)) {
+		return true
+	}
+	if hasAccessList(a) && hasAccessList(b) {
+		return accessListsConflict(a, b)
+	}
+	keyA := s.messageKey(a)
+	keyB := s.messageKey(b)
+	if keyA == keyB {
+		return true
+	}
+	if s.conflicting(keyA, keyB) {
+		if _, ok := s.optimisticallyCleared(keyA, keyB); !ok {
+			return true
+		}
+	}
+	if s.touchesPrefixFor(a, keyB) || s.touchesPrefixFor(b, keyA) {
+		return true
+	}
+	if s.pathProfileOverlapLocked(keyA, keyB) {
+		return true
+	}
+	if s.callGraphConflictLocked(keyA, keyB) {
+		return true
+	}
+	return s.crossCheckTransfers && s.transferConflictsWithBalanceTouch(a, b)
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import "testing"
+
+func TestDAGHasNoEdgesForFullyIndependentMessages(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+	})
+
+	dag := s.DAG(sched)
+	if len(dag[1]) != 0 || len(dag[2]) != 0 {
+		t.Fatalf("expected no prerequisites for independent messages, got %v", dag)
+	}
+}
+
+func TestDAGRecordsThePrerequisiteThatForcedTheLaterGeneration(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"shared"}},
+		{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"shared"}},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the conflicting pair to land in separate generations, got %+v", sched.Generations)
+	}
+
+	dag := s.DAG(sched)
+	if len(dag[2]) != 1 || dag[2][0] != 1 {
+		t.Fatalf("expected message 2 to depend on message 1, got %v", dag[2])
+	}
+	if len(dag[1]) != 0 {
+		t.Fatalf("expected message 1 to have no prerequisites, got %v", dag[1])
+	}
+}
+
+func TestDAGDoesNotDuplicateOptimisticDecisionsAlreadyRecordedByNew(t *testing.T) {
+	s := NewScheduler()
+	s.SetOptimisticConflictRate(1)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	before := len(s.optimisticDecisions)
+
+	s.DAG(sched)
+
+	if len(s.optimisticDecisions) != before {
+		t.Fatalf("expected DAG not to record additional optimistic decisions, had %d now have %d", before, len(s.optimisticDecisions))
+	}
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import "testing"
+
+func TestSetDeferDepthSpreadsRepeatedCalleeAcrossLevels(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferThreshold(1)
+	s.SetDeferDepth(2)
+
+	// Cross the threshold once so the callee is deferrable from here on.
+	s.New([]*Message{{ID: 100, To: "0xhot", Sig: "f()"}})
+
+	msgs := make([]*Message, 4)
+	for i := range msgs {
+		msgs[i] = &Message{ID: uint64(i + 1), To: "0xhot", Sig: "f()"}
+	}
+	sched := s.New(msgs)
+
+	if len(sched.Deferred) != 4 {
+		t.Fatalf("expected all 4 hot calls deferred, got %v", sched.Deferred)
+	}
+	if len(sched.DeferredLevels) != 2 {
+		t.Fatalf("expected 2 deferred levels, got %d", len(sched.DeferredLevels))
+	}
+	if len(sched.DeferredLevels[0]) != 2 || len(sched.DeferredLevels[1]) != 2 {
+		t.Fatalf("expected each level to get 2 messages, got %v", sched.DeferredLevels)
+	}
+}
+
+func TestDeferDepthDefaultsToASingleLevel(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferThreshold(1)
+
+	s.New([]*Message{{ID: 100, To: "0xhot", Sig: "f()"}})
+	sched := s.New([]*Message{{ID: 1, To: "0xhot", Sig: "f()"}, {ID: 2, To: "0xhot", Sig: "f()"}})
+
+	if len(sched.DeferredLevels) != 1 || len(sched.DeferredLevels[0]) != 2 {
+		t.Fatalf("expected a single deferred level with both messages, got %v", sched.DeferredLevels)
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/deferral"
+)
+
+func TestSetDeferPolicyOverridesThresholdForOneCallee(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferrable("0xcold", "g()", true)
+	// No Scheduler-wide threshold configured (deferral disabled globally).
+	s.SetDeferPolicy("0xhot", "f()", &DeferPolicy{MinInstances: 1})
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xhot", Sig: "f()"},
+		{ID: 2, To: "0xcold", Sig: "g()"},
+	})
+
+	if len(sched.Deferred) != 1 || sched.Deferred[0].ID != 1 {
+		t.Fatalf("expected only the per-key-overridden callee deferred, got %v", sched.Deferred)
+	}
+}
+
+func TestSetDeferPolicyOverridesRoundsForOneCallee(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferThreshold(1)
+	s.SetDeferDepth(1)
+	s.SetDeferPolicy("0xhot", "f()", &DeferPolicy{MaxRounds: 2})
+
+	s.New([]*Message{{ID: 100, To: "0xhot", Sig: "f()"}})
+	sched := s.New([]*Message{{ID: 1, To: "0xhot", Sig: "f()"}, {ID: 2, To: "0xhot", Sig: "f()"}})
+
+	if len(sched.DeferredLevels) != 2 {
+		t.Fatalf("expected the per-callee MaxRounds override to spread across 2 levels, got %v", sched.DeferredLevels)
+	}
+}
+
+func TestSetDeferPolicyHighestGasTiebreakFavorsHigherGasPrice(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferThreshold(1)
+	s.SetDeferDepth(2)
+	s.SetDeferPolicy("0xhot", "f()", &DeferPolicy{Tiebreak: deferral.TiebreakHighestGas})
+
+	s.New([]*Message{{ID: 100, To: "0xhot", Sig: "f()"}})
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xhot", Sig: "f()", GasPrice: 5},
+		{ID: 2, To: "0xhot", Sig: "f()", GasPrice: 50},
+	})
+
+	if len(sched.DeferredLevels) != 2 || len(sched.DeferredLevels[0]) != 1 || sched.DeferredLevels[0][0].ID != 2 {
+		t.Fatalf("expected the highest-gas message to land on the earliest level, got %v", sched.DeferredLevels)
+	}
+}
+
+func TestClearingDeferPolicyRevertsToSchedulerDefaults(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xhot", "f()", true)
+	s.SetDeferPolicy("0xhot", "f()", &DeferPolicy{MinInstances: 1})
+	s.SetDeferPolicy("0xhot", "f()", nil)
+
+	sched := s.New([]*Message{{ID: 1, To: "0xhot", Sig: "f()"}})
+	if len(sched.Deferred) != 0 {
+		t.Fatalf("expected no deferral once the policy override is cleared, got %v", sched.Deferred)
+	}
+}
@@ -0,0 +1,124 @@
+// Package deferral decides which messages in a batch should be pushed
+// into a follow-up generation rather than scheduled now. It is shared by
+// Scheduler.New and Scheduler.Optimize so the two code paths can't drift
+// on what "deferrable" means.
+package deferral
+
+import "sort"
+
+// Candidate is a message as seen by the planner: just enough to look up
+// its callee's learned statistics and, for Tiebreak, break ties among
+// several deferred instances of the same callee.
+type Candidate struct {
+	ID        uint64
+	CalleeKey string
+	GasPrice  uint64
+}
+
+// Tiebreak selects which of a callee's deferred instances within one
+// batch are favored for the earliest (soonest-run) follow-up level, once
+// there are more deferred instances than levels to spread them evenly
+// across.
+type Tiebreak int
+
+const (
+	// TiebreakArrival assigns levels in the order candidates were given
+	// to PlanLevels — the default.
+	TiebreakArrival Tiebreak = iota
+	// TiebreakLastArriving favors the most recently arrived instances
+	// for the earliest levels.
+	TiebreakLastArriving
+	// TiebreakHighestGas favors the highest-GasPrice instances for the
+	// earliest levels.
+	TiebreakHighestGas
+)
+
+// Planner decides which messages to defer based on how often their callee
+// has been called and whether the callee is explicitly deferrable.
+// Threshold and Depth are the batch-wide defaults; PerKeyMinInstances,
+// PerKeyDepth, and PerKeyTiebreak let a specific callee override them
+// (see Scheduler.SetDeferPolicy).
+type Planner struct {
+	CallCounts map[string]uint64
+	Deferrable map[string]bool
+	Threshold  uint64
+	// Depth is how many follow-up levels a deferred callee's repeated
+	// calls are spread across (see PlanLevels). 0 and 1 both mean a
+	// single level, matching Plan's original behavior.
+	Depth int
+	// PerKeyMinInstances overrides Threshold for specific callee keys.
+	PerKeyMinInstances map[string]uint64
+	// PerKeyDepth overrides Depth for specific callee keys.
+	PerKeyDepth map[string]int
+	// PerKeyTiebreak overrides the default arrival-order tiebreak for
+	// specific callee keys.
+	PerKeyTiebreak map[string]Tiebreak
+}
+
+// Plan returns the IDs of candidates that should be deferred. A Threshold
+// of 0 means deferral is disabled and Plan always returns nil, unless a
+// PerKeyMinInstances override applies to a candidate's callee.
+func (p *Planner) Plan(candidates []Candidate) []uint64 {
+	levels := p.PlanLevels(candidates)
+	if len(levels) == 0 {
+		return nil
+	}
+	deferred := make([]uint64, 0, len(levels))
+	for _, c := range candidates {
+		if _, ok := levels[c.ID]; ok {
+			deferred = append(deferred, c.ID)
+		}
+	}
+	return deferred
+}
+
+// PlanLevels behaves like Plan, but also assigns each deferred candidate
+// a level in [0, depth): repeated candidates for the same callee are
+// spread across the available levels instead of all landing on level 0,
+// so a contract hot enough to defer many calls in one batch still gains
+// parallelism once those calls run — each level packs and executes
+// independently a block further out than the last, rather than
+// serializing behind a single follow-up generation. Within a callee's
+// group of deferred candidates, Tiebreak decides which ones land on the
+// earliest levels; the default, TiebreakArrival, just uses the order
+// candidates were given.
+func (p *Planner) PlanLevels(candidates []Candidate) map[uint64]int {
+	groups := make(map[string][]Candidate)
+	for _, c := range candidates {
+		if !p.Deferrable[c.CalleeKey] {
+			continue
+		}
+		threshold := p.Threshold
+		if t, ok := p.PerKeyMinInstances[c.CalleeKey]; ok {
+			threshold = t
+		}
+		if threshold == 0 || p.CallCounts[c.CalleeKey] < threshold {
+			continue
+		}
+		groups[c.CalleeKey] = append(groups[c.CalleeKey], c)
+	}
+
+	levels := make(map[uint64]int)
+	for key, group := range groups {
+		switch p.PerKeyTiebreak[key] {
+		case TiebreakLastArriving:
+			for i, j := 0, len(group)-1; i < j; i, j = i+1, j-1 {
+				group[i], group[j] = group[j], group[i]
+			}
+		case TiebreakHighestGas:
+			sort.SliceStable(group, func(i, j int) bool { return group[i].GasPrice > group[j].GasPrice })
+		}
+
+		depth := p.Depth
+		if d, ok := p.PerKeyDepth[key]; ok {
+			depth = d
+		}
+		if depth < 1 {
+			depth = 1
+		}
+		for i, c := range group {
+			levels[c.ID] = i % depth
+		}
+	}
+	return levels
+}
@@ -0,0 +1,137 @@
+package deferral
+
+import "testing"
+
+func TestPlanDefersOnlyDeferrableOverThreshold(t *testing.T) {
+	p := &Planner{
+		CallCounts: map[string]uint64{"hot": 10, "cold": 1},
+		Deferrable: map[string]bool{"hot": true, "cold": true},
+		Threshold:  5,
+	}
+
+	deferred := p.Plan([]Candidate{{ID: 1, CalleeKey: "hot"}, {ID: 2, CalleeKey: "cold"}})
+	if len(deferred) != 1 || deferred[0] != 1 {
+		t.Fatalf("expected only the hot candidate deferred, got %v", deferred)
+	}
+}
+
+func TestPlanDisabledByZeroThreshold(t *testing.T) {
+	p := &Planner{CallCounts: map[string]uint64{"hot": 100}, Deferrable: map[string]bool{"hot": true}}
+	if got := p.Plan([]Candidate{{ID: 1, CalleeKey: "hot"}}); got != nil {
+		t.Fatalf("expected nil deferral with zero threshold, got %v", got)
+	}
+}
+
+func TestPlanLevelsSpreadsRepeatedCalleeAcrossDepth(t *testing.T) {
+	p := &Planner{
+		CallCounts: map[string]uint64{"hot": 10},
+		Deferrable: map[string]bool{"hot": true},
+		Threshold:  5,
+		Depth:      3,
+	}
+	candidates := make([]Candidate, 6)
+	for i := range candidates {
+		candidates[i] = Candidate{ID: uint64(i + 1), CalleeKey: "hot"}
+	}
+
+	levels := p.PlanLevels(candidates)
+	if len(levels) != 6 {
+		t.Fatalf("expected all 6 candidates deferred, got %v", levels)
+	}
+	counts := make(map[int]int)
+	for _, lvl := range levels {
+		if lvl < 0 || lvl >= 3 {
+			t.Fatalf("level %d out of range [0,3)", lvl)
+		}
+		counts[lvl]++
+	}
+	for lvl, count := range counts {
+		if count != 2 {
+			t.Fatalf("expected each of 3 levels to get 2 candidates, level %d got %d", lvl, count)
+		}
+	}
+}
+
+func TestPlanLevelsDefaultsToASingleLevel(t *testing.T) {
+	p := &Planner{
+		CallCounts: map[string]uint64{"hot": 10},
+		Deferrable: map[string]bool{"hot": true},
+		Threshold:  5,
+	}
+	levels := p.PlanLevels([]Candidate{{ID: 1, CalleeKey: "hot"}, {ID: 2, CalleeKey: "hot"}})
+	for id, lvl := range levels {
+		if lvl != 0 {
+			t.Fatalf("expected candidate %d to land on level 0 with Depth unset, got %d", id, lvl)
+		}
+	}
+}
+
+func TestPerKeyMinInstancesOverridesGlobalThreshold(t *testing.T) {
+	p := &Planner{
+		CallCounts:         map[string]uint64{"hot": 2},
+		Deferrable:         map[string]bool{"hot": true},
+		Threshold:          0,
+		PerKeyMinInstances: map[string]uint64{"hot": 1},
+	}
+	deferred := p.Plan([]Candidate{{ID: 1, CalleeKey: "hot"}})
+	if len(deferred) != 1 || deferred[0] != 1 {
+		t.Fatalf("expected the per-key override to defer despite a zero global threshold, got %v", deferred)
+	}
+}
+
+func TestPerKeyDepthOverridesGlobalDepth(t *testing.T) {
+	p := &Planner{
+		CallCounts:  map[string]uint64{"hot": 10},
+		Deferrable:  map[string]bool{"hot": true},
+		Threshold:   1,
+		Depth:       1,
+		PerKeyDepth: map[string]int{"hot": 2},
+	}
+	candidates := []Candidate{{ID: 1, CalleeKey: "hot"}, {ID: 2, CalleeKey: "hot"}}
+	levels := p.PlanLevels(candidates)
+	if levels[1] == levels[2] {
+		t.Fatalf("expected the per-key depth override to spread candidates across levels, got %v", levels)
+	}
+}
+
+func TestTiebreakHighestGasFavorsHigherGasForTheEarliestLevel(t *testing.T) {
+	p := &Planner{
+		CallCounts:     map[string]uint64{"hot": 10},
+		Deferrable:     map[string]bool{"hot": true},
+		Threshold:      1,
+		Depth:          2,
+		PerKeyTiebreak: map[string]Tiebreak{"hot": TiebreakHighestGas},
+	}
+	candidates := []Candidate{
+		{ID: 1, CalleeKey: "hot", GasPrice: 10},
+		{ID: 2, CalleeKey: "hot", GasPrice: 99},
+	}
+	levels := p.PlanLevels(candidates)
+	if levels[2] != 0 {
+		t.Fatalf("expected the highest-gas candidate to land on level 0, got %v", levels)
+	}
+	if levels[1] != 1 {
+		t.Fatalf("expected the lower-gas candidate to land on a later level, got %v", levels)
+	}
+}
+
+func TestTiebreakLastArrivingFavorsMostRecentForTheEarliestLevel(t *testing.T) {
+	p := &Planner{
+		CallCounts:     map[string]uint64{"hot": 10},
+		Deferrable:     map[string]bool{"hot": true},
+		Threshold:      1,
+		Depth:          2,
+		PerKeyTiebreak: map[string]Tiebreak{"hot": TiebreakLastArriving},
+	}
+	candidates := []Candidate{
+		{ID: 1, CalleeKey: "hot"},
+		{ID: 2, CalleeKey: "hot"},
+	}
+	levels := p.PlanLevels(candidates)
+	if levels[2] != 0 {
+		t.Fatalf("expected the most recently arrived candidate to land on level 0, got %v", levels)
+	}
+	if levels[1] != 1 {
+		t.Fatalf("expected the earlier-arriving candidate to land on a later level, got %v", levels)
+	}
+}
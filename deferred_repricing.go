@@ -0,0 +1,34 @@
+package scheduler
+
+// DeferredAnnotation records that a deferred message's gas price was
+// adjusted before its retry, and by how much, so the change is auditable
+// rather than a silent mutation of the message.
+type DeferredAnnotation struct {
+	MessageID        uint64
+	OriginalGasPrice uint64
+	RepricedGasPrice uint64
+}
+
+// RepriceFunc computes the gas price a deferred message should carry into
+// its retry, given the message as originally submitted.
+type RepriceFunc func(m *Message) uint64
+
+// RepriceDeferred applies reprice to every message in sched.Deferred,
+// updating its GasPrice in place and returning an annotation per message
+// so callers can see exactly what changed before the retry. Deferred
+// messages commonly need repricing (e.g. EIP-1559 style base fee
+// adjustment) since they run against a later block than the one they were
+// originally submitted for.
+func (s *Scheduler) RepriceDeferred(sched *Schedule, reprice RepriceFunc) []DeferredAnnotation {
+	annotations := make([]DeferredAnnotation, 0, len(sched.Deferred))
+	for _, m := range sched.Deferred {
+		original := m.GasPrice
+		m.GasPrice = reprice(m)
+		annotations = append(annotations, DeferredAnnotation{
+			MessageID:        m.ID,
+			OriginalGasPrice: original,
+			RepricedGasPrice: m.GasPrice,
+		})
+	}
+	return annotations
+}
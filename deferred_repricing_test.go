@@ -0,0 +1,17 @@
+package scheduler
+
+import "testing"
+
+func TestRepriceDeferredUpdatesGasPriceAndAnnotates(t *testing.T) {
+	s := NewScheduler()
+	sched := &Schedule{Deferred: []*Message{{ID: 1, GasPrice: 10}}}
+
+	annotations := s.RepriceDeferred(sched, func(m *Message) uint64 { return m.GasPrice * 2 })
+
+	if sched.Deferred[0].GasPrice != 20 {
+		t.Fatalf("expected deferred message's gas price to be updated, got %d", sched.Deferred[0].GasPrice)
+	}
+	if len(annotations) != 1 || annotations[0].OriginalGasPrice != 10 || annotations[0].RepricedGasPrice != 20 {
+		t.Fatalf("unexpected annotations: %+v", annotations)
+	}
+}
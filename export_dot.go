@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportConflictGraph writes the learned callee conflict graph to w in
+// DOT format, one undirected edge per conflicting pair, so it can be
+// rendered with Graphviz (e.g. `dot -Tsvg`) to see which contracts
+// serialize each other. Nodes are labeled with the callee's address and
+// signature when known (see Callee), falling back to the raw callee key
+// otherwise. Edges are emitted in sorted order for a stable, diffable
+// output across runs.
+func (s *Scheduler) ExportConflictGraph(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := io.WriteString(w, "graph conflicts {\n"); err != nil {
+		return err
+	}
+
+	nodes := make(map[string]struct{}, len(s.conflicts))
+	type edge struct{ a, b string }
+	var edges []edge
+	for a, peers := range s.conflicts {
+		nodes[a] = struct{}{}
+		for b := range peers {
+			nodes[b] = struct{}{}
+			if a < b {
+				edges = append(edges, edge{a, b})
+			}
+		}
+	}
+
+	sortedNodes := make([]string, 0, len(nodes))
+	for n := range nodes {
+		sortedNodes = append(sortedNodes, n)
+	}
+	sort.Strings(sortedNodes)
+
+	for _, n := range sortedNodes {
+		if _, err := fmt.Fprintf(w, "  %s [label=%s];\n", dotID(n), strconv.Quote(s.nodeLabel(n))); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+		return edges[i].b < edges[j].b
+	})
+	for _, e := range edges {
+		if _, err := fmt.Fprintf(w, "  %s -- %s;\n", dotID(e.a), dotID(e.b)); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+// nodeLabel returns "address\nsignature" for a known callee, falling back
+// to the raw callee key if the callee has since been evicted from the
+// store.
+func (s *Scheduler) nodeLabel(calleeKey string) string {
+	if c, ok := s.calleeDict.Get(calleeKey); ok {
+		return c.Address + "\n" + c.Signature
+	}
+	return calleeKey
+}
+
+// dotID turns a callee key into a syntactically valid, stable DOT
+// identifier by replacing every character other than an ASCII letter,
+// digit, or underscore with "_".
+func dotID(calleeKey string) string {
+	return "n_" + strings.Map(func(r rune) rune {
+		if r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, calleeKey)
+}
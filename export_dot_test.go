@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportConflictGraphIncludesLabeledNodesAndEdges(t *testing.T) {
+	s := NewScheduler()
+	s.callee("0xa", "f()")
+	s.callee("0xb", "g()")
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	var buf strings.Builder
+	if err := s.ExportConflictGraph(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "graph conflicts {") || !strings.HasSuffix(strings.TrimSpace(out), "}") {
+		t.Fatalf("expected a well-formed DOT graph, got %q", out)
+	}
+	if !strings.Contains(out, `label="0xa\nf()"`) {
+		t.Fatalf("expected node label with address and signature, got %q", out)
+	}
+	if !strings.Contains(out, "--") {
+		t.Fatalf("expected an edge between the conflicting callees, got %q", out)
+	}
+}
+
+func TestExportConflictGraphOmitsUnrelatedCallees(t *testing.T) {
+	s := NewScheduler()
+	s.callee("0xa", "f()")
+
+	var buf strings.Builder
+	if err := s.ExportConflictGraph(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "0xa") {
+		t.Fatalf("expected a callee with no learned conflicts to be absent from the graph, got %q", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+// defaultFilterCaptureLimit bounds how many filtered items filterStats
+// keeps by default, so a pathological input can't turn observability
+// into its own memory leak.
+const defaultFilterCaptureLimit = 100
+
+// FilteredItem records one pair or transition that the conflict pipeline
+// silently dropped or diverted, e.g. a self-conflicting pair that Add
+// refuses to record. Reason is a short, stable string identifying why.
+type FilteredItem struct {
+	Reason string
+	Detail string
+}
+
+// FilterStats summarizes what a Scheduler's conflict pipeline has
+// silently dropped or diverted since it was created, so that kind of
+// data loss — previously invisible — can be surfaced after a block.
+type FilterStats struct {
+	Filtered   uint64
+	Captured   []FilteredItem
+	captureCap int
+}
+
+func newFilterStats() *FilterStats {
+	return &FilterStats{captureCap: defaultFilterCaptureLimit}
+}
+
+func (fs *FilterStats) record(reason, detail string) {
+	fs.Filtered++
+	if len(fs.Captured) < fs.captureCap {
+		fs.Captured = append(fs.Captured, FilteredItem{Reason: reason, Detail: detail})
+	}
+}
+
+// SetFilterCaptureLimit caps how many FilteredItems FilterStats retains
+// for inspection; the running Filtered count is unaffected. 0 disables
+// capture entirely while still counting.
+func (s *Scheduler) SetFilterCaptureLimit(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filterStats.captureCap = n
+	if len(s.filterStats.Captured) > n {
+		s.filterStats.Captured = s.filterStats.Captured[:n]
+	}
+}
+
+// FilterStats returns a snapshot of everything the pipeline has silently
+// dropped or diverted so far — e.g. self-conflicting pairs from Add or
+// ImportPairsFile — so operators can tell when input data is quietly
+// being thrown away instead of assuming it was all recorded.
+func (s *Scheduler) FilterStats() FilterStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	captured := make([]FilteredItem, len(s.filterStats.Captured))
+	copy(captured, s.filterStats.Captured)
+	return FilterStats{Filtered: s.filterStats.Filtered, Captured: captured}
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAddRecordsFilteredSelfConflict(t *testing.T) {
+	s := NewScheduler()
+	s.Add("a", "a")
+	s.Add("a", "b")
+
+	stats := s.FilterStats()
+	if stats.Filtered != 1 {
+		t.Fatalf("expected 1 filtered self-conflict, got %d", stats.Filtered)
+	}
+	if len(stats.Captured) != 1 || stats.Captured[0].Reason != "self-conflict" {
+		t.Fatalf("expected the self-conflict to be captured, got %v", stats.Captured)
+	}
+}
+
+func TestSetFilterCaptureLimitCapsBufferButNotCount(t *testing.T) {
+	s := NewScheduler()
+	s.SetFilterCaptureLimit(1)
+	s.Add("a", "a")
+	s.Add("b", "b")
+
+	stats := s.FilterStats()
+	if stats.Filtered != 2 {
+		t.Fatalf("expected the running count to keep counting past the cap, got %d", stats.Filtered)
+	}
+	if len(stats.Captured) != 1 {
+		t.Fatalf("expected capture buffer to stay capped at 1, got %v", stats.Captured)
+	}
+}
+
+func TestImportPairsFileSurfacesSelfPairsAsFiltered(t *testing.T) {
+	path := t.TempDir() + "/pairs.csv"
+	if err := os.WriteFile(path, []byte("0xa,f(),0xa,f()\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScheduler()
+	if _, err := s.ImportPairsFile(path, FormatCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.FilterStats().Filtered != 1 {
+		t.Fatalf("expected the self-pair to be recorded as filtered, got %+v", s.FilterStats())
+	}
+}
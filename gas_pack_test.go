@@ -0,0 +1,53 @@
+package scheduler
+
+import "testing"
+
+func TestNewSplitsGenerationWhenGasBudgetExceeded(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerationGas(15)
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10},
+		{ID: 2, To: "0xB", Sig: "g()", GasLimit: 10},
+	}
+	sched := s.New(msgs)
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the gas budget to force a second generation, got %v", sched.Generations)
+	}
+	for i, gas := range sched.GenerationGas {
+		if gas > 15 {
+			t.Fatalf("generation %d exceeded the gas budget: %d", i, gas)
+		}
+	}
+}
+
+func TestNewPacksUnderGasBudgetTogether(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerationGas(1000)
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10},
+		{ID: 2, To: "0xB", Sig: "g()", GasLimit: 10},
+	}
+	sched := s.New(msgs)
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both messages to fit within budget in one generation, got %v", sched.Generations)
+	}
+}
+
+func TestCompactGenerationsRespectsMaxGenerationGas(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerationGas(15)
+
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10}},
+			{{ID: 2, To: "0xB", Sig: "g()", GasLimit: 10}},
+		},
+		GenerationGas: []uint64{10, 10},
+	}
+	compacted := s.compactGenerations(sched)
+	if len(compacted.Generations) != 2 {
+		t.Fatalf("expected the gas budget to block the merge, got %v", compacted.Generations)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import "testing"
+
+func TestSequentialTailIsOrderedByGasPriceAcrossOverflowGenerations(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerations(1)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xc", "h()"))
+	s.Add(calleeKey("0xb", "g()"), calleeKey("0xc", "h()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasPrice: 10},
+		{ID: 2, To: "0xB", Sig: "g()", GasPrice: 30},
+		{ID: 3, To: "0xC", Sig: "h()", GasPrice: 20},
+	})
+
+	if len(sched.SequentialTail) != 2 {
+		t.Fatalf("expected 2 messages pushed into the sequential tail, got %v", sched.SequentialTail)
+	}
+	if sched.SequentialTail[0].GasPrice < sched.SequentialTail[1].GasPrice {
+		t.Fatalf("expected the sequential tail ordered by descending GasPrice, got %+v", sched.SequentialTail)
+	}
+}
+
+func TestSequentialTailGasPriceSortDoesNotInvertSameSenderNonceOrder(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerations(1)
+
+	sched := s.New([]*Message{
+		{ID: 1, From: "0xsender", To: "0xA", Sig: "f()", Nonce: 0, GasPrice: 200},
+		{ID: 2, From: "0xsender", To: "0xA", Sig: "f()", Nonce: 1, GasPrice: 10},
+		{ID: 3, From: "0xsender", To: "0xA", Sig: "f()", Nonce: 2, GasPrice: 100},
+	})
+
+	if len(sched.SequentialTail) != 2 {
+		t.Fatalf("expected 2 messages pushed into the sequential tail, got %+v", sched.SequentialTail)
+	}
+	if sched.SequentialTail[0].Nonce > sched.SequentialTail[1].Nonce {
+		t.Fatalf("expected the tail to keep ascending-nonce order despite differing gas prices, got %+v", sched.SequentialTail)
+	}
+}
+
+func TestSequentialTailGasPriceSortDoesNotInvertConstraintOrder(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerations(1)
+	s.AddConstraint(100, 1)
+	s.AddConstraint(1, 2)
+
+	sched := s.New([]*Message{
+		{ID: 100, To: "0xA", Sig: "f()", GasPrice: 500},
+		{ID: 1, To: "0xB", Sig: "g()", GasPrice: 10},
+		{ID: 2, To: "0xC", Sig: "h()", GasPrice: 200},
+	})
+
+	var tailIDs []uint64
+	for _, m := range sched.SequentialTail {
+		tailIDs = append(tailIDs, m.ID)
+	}
+	pos := make(map[uint64]int, len(tailIDs))
+	for i, id := range tailIDs {
+		pos[id] = i
+	}
+	if _, ok := pos[100]; ok {
+		if p1, ok1 := pos[1]; ok1 && pos[100] > p1 {
+			t.Fatalf("expected 100 to stay ahead of 1 in the tail, got %v", tailIDs)
+		}
+	}
+	if p1, ok1 := pos[1]; ok1 {
+		if p2, ok2 := pos[2]; ok2 && p1 > p2 {
+			t.Fatalf("expected 1 to stay ahead of 2 in the tail, got %v", tailIDs)
+		}
+	}
+}
+
+func TestGenerationOrderRespectsACustomPriorityComparator(t *testing.T) {
+	s := NewScheduler()
+	s.SetPriorityComparator(func(a, b *Message) bool { return a.ID < b.ID })
+	s.SetMaxGenerations(1)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 5, To: "0xA", Sig: "f()", GasPrice: 1},
+		{ID: 1, To: "0xB", Sig: "g()", GasPrice: 99},
+	})
+
+	if len(sched.SequentialTail) != 1 {
+		t.Fatalf("expected exactly one message pushed into the sequential tail, got %v", sched.SequentialTail)
+	}
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 1 {
+		t.Fatalf("expected the other message alone in a single generation, got %+v", sched.Generations)
+	}
+	if sched.Generations[0][0].ID != 1 {
+		t.Fatalf("expected the ascending-ID comparator to keep ID 1 ahead of ID 5, got generation %+v", sched.Generations[0])
+	}
+}
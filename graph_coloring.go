@@ -0,0 +1,125 @@
+package scheduler
+
+import "context"
+
+// packByGraphColoring assigns msgs to generations by building an explicit
+// conflict graph (an edge between any two messages messagesConflict
+// flags) and coloring it with DSATUR: repeatedly picking the uncolored
+// vertex touching the most distinct colors so far (breaking ties by raw
+// degree), and giving it the smallest color not already used by a
+// neighbor. Each color becomes one generation. DSATUR is a heuristic, not
+// an exact minimum coloring, but tends to beat greedy first-fit packing
+// on dense conflict graphs where greedy's placement order matters most.
+// ctx is checked while building the O(n^2) adjacency matrix, the
+// dominant cost on a large batch; a canceled context aborts before
+// coloring starts and reports it via the returned error, alongside the
+// entire batch as the unprocessed remainder so newLocked can fall back
+// to running it through the sequential set rather than dropping it.
+func (s *Scheduler) packByGraphColoring(ctx context.Context, msgs []*Message) ([][]*Message, []uint64, []*Message, error) {
+	n := len(msgs)
+	if n == 0 {
+		return nil, nil, nil, nil
+	}
+
+	adjacent := make([][]bool, n)
+	degree := make([]int, n)
+	for i := 0; i < n; i++ {
+		adjacent[i] = make([]bool, n)
+	}
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, msgs, err
+		}
+		for j := i + 1; j < n; j++ {
+			if s.messagesConflict(msgs[i], msgs[j]) {
+				adjacent[i][j] = true
+				adjacent[j][i] = true
+				degree[i]++
+				degree[j]++
+			}
+		}
+	}
+
+	const uncolored = -1
+	color := make([]int, n)
+	for i := range color {
+		color[i] = uncolored
+	}
+	neighborColors := make([]map[int]struct{}, n)
+	for i := range neighborColors {
+		neighborColors[i] = make(map[int]struct{})
+	}
+
+	numColors := 0
+	for colored := 0; colored < n; colored++ {
+		next := -1
+		for i := 0; i < n; i++ {
+			if color[i] != uncolored {
+				continue
+			}
+			if next == -1 ||
+				len(neighborColors[i]) > len(neighborColors[next]) ||
+				(len(neighborColors[i]) == len(neighborColors[next]) && degree[i] > degree[next]) {
+				next = i
+			}
+		}
+
+		c := 0
+		for {
+			if _, used := neighborColors[next][c]; !used {
+				break
+			}
+			c++
+		}
+		color[next] = c
+		if c+1 > numColors {
+			numColors = c + 1
+		}
+		for j := 0; j < n; j++ {
+			if adjacent[next][j] && color[j] == uncolored {
+				neighborColors[j][c] = struct{}{}
+			}
+		}
+	}
+
+	gens := make([][]*Message, numColors)
+	for i, m := range msgs {
+		gens[color[i]] = append(gens[color[i]], m)
+	}
+	outGens, outGas := s.splitOversizedColorClasses(gens)
+	return outGens, outGas, nil, nil
+}
+
+// splitOversizedColorClasses enforces SetMaxGenerationSize and
+// SetMaxGenerationGas against DSATUR's color classes. A color class has
+// no conflicts within it by construction, so it can be cut into however
+// many smaller generations are needed to respect either cap without
+// re-checking messagesConflict; this mirrors how packGreedily and
+// compactGenerations already apply the same two caps, just as a
+// post-processing pass instead of inline while packing.
+func (s *Scheduler) splitOversizedColorClasses(gens [][]*Message) ([][]*Message, []uint64) {
+	var outGens [][]*Message
+	var outGas []uint64
+	for _, gen := range gens {
+		var cur []*Message
+		var curGas uint64
+		for _, m := range gen {
+			mg := s.estimatedGas(m)
+			if len(cur) > 0 &&
+				((s.maxGenSize > 0 && len(cur)+1 > s.maxGenSize) ||
+					(s.maxGenGas > 0 && curGas+mg > s.maxGenGas)) {
+				outGens = append(outGens, cur)
+				outGas = append(outGas, curGas)
+				cur = nil
+				curGas = 0
+			}
+			cur = append(cur, m)
+			curGas += mg
+		}
+		if len(cur) > 0 {
+			outGens = append(outGens, cur)
+			outGas = append(outGas, curGas)
+		}
+	}
+	return outGens, outGas
+}
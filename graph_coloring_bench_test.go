@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkNewStrategies compares StrategyGreedy against
+// StrategyGraphColoring on a dense conflict graph (each callee conflicts
+// with its two neighbors in a ring), the case StrategyGraphColoring was
+// added for.
+func BenchmarkNewStrategies(b *testing.B) {
+	for _, n := range []int{10, 50, 200} {
+		msgs := make([]*Message, n)
+		for i := 0; i < n; i++ {
+			msgs[i] = &Message{ID: uint64(i), To: fmt.Sprintf("0x%d", i), Sig: "f()"}
+		}
+		buildRingConflicts := func(s *Scheduler) {
+			for i := 0; i < n; i++ {
+				a := calleeKey(fmt.Sprintf("0x%d", i), "f()")
+				b := calleeKey(fmt.Sprintf("0x%d", (i+1)%n), "f()")
+				s.Add(a, b)
+			}
+		}
+
+		b.Run(fmt.Sprintf("greedy/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := NewScheduler()
+				buildRingConflicts(s)
+				s.New(msgs)
+			}
+		})
+		b.Run(fmt.Sprintf("graphColoring/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := NewScheduler()
+				s.SetStrategy(StrategyGraphColoring)
+				buildRingConflicts(s)
+				s.New(msgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import "testing"
+
+func TestGraphColoringSeparatesConflictingCallees(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyGraphColoring)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected conflicting callees split across generations, got %v", sched.Generations)
+	}
+}
+
+func TestGraphColoringPacksUnrelatedCalleesTogether(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyGraphColoring)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both unrelated messages in one generation, got %v", sched.Generations)
+	}
+}
+
+func TestGraphColoringUsesFewerOrEqualGenerationsThanGreedyOnDenseGraph(t *testing.T) {
+	// A cyclic conflict chain (0-1, 1-2, 2-3, 3-0) is the classic case
+	// where first-fit greedy packing order can waste a generation that a
+	// coloring approach avoids.
+	build := func() []*Message {
+		return []*Message{
+			{ID: 1, To: "0xA", Sig: "f()"},
+			{ID: 2, To: "0xB", Sig: "g()"},
+			{ID: 3, To: "0xC", Sig: "h()"},
+			{ID: 4, To: "0xD", Sig: "i()"},
+		}
+	}
+	addCycle := func(s *Scheduler) {
+		s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+		s.Add(calleeKey("0xb", "g()"), calleeKey("0xc", "h()"))
+		s.Add(calleeKey("0xc", "h()"), calleeKey("0xd", "i()"))
+		s.Add(calleeKey("0xd", "i()"), calleeKey("0xa", "f()"))
+	}
+
+	greedy := NewScheduler()
+	addCycle(greedy)
+	greedySched := greedy.New(build())
+
+	colored := NewScheduler()
+	colored.SetStrategy(StrategyGraphColoring)
+	addCycle(colored)
+	coloredSched := colored.New(build())
+
+	if len(coloredSched.Generations) > len(greedySched.Generations) {
+		t.Fatalf("expected graph coloring to use at most as many generations as greedy, greedy=%d colored=%d",
+			len(greedySched.Generations), len(coloredSched.Generations))
+	}
+}
+
+func TestGraphColoringSplitsAColorClassThatExceedsMaxGenerationGas(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyGraphColoring)
+	s.SetMaxGenerationGas(150)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasLimit: 100},
+		{ID: 2, To: "0xB", Sig: "g()", GasLimit: 100},
+		{ID: 3, To: "0xC", Sig: "h()", GasLimit: 100},
+	})
+	if len(sched.Generations) != 3 {
+		t.Fatalf("expected the gas cap to split the unconflicted batch into 3 generations, got %v", sched.Generations)
+	}
+	for i, gas := range sched.GenerationGas {
+		if gas > 150 {
+			t.Fatalf("generation %d exceeded MaxGenerationGas: %d", i, gas)
+		}
+	}
+}
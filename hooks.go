@@ -0,0 +1,58 @@
+package scheduler
+
+// Hooks are optional callbacks a Scheduler invokes as scheduling events
+// happen, for an embedding service that wants to log, meter, or audit
+// decisions without modifying scheduler package internals. Unlike
+// metrics.Observer, which reports plain counts and latencies, Hooks
+// carry the actual callee keys, message, and Schedule involved. Like
+// Observer, every hook must return promptly — it runs synchronously,
+// with the Scheduler's internal lock held, so a hook that blocks or
+// calls back into the same Scheduler will stall or deadlock it — and is
+// not a veto point: by the time a hook fires, the event it describes has
+// already happened. A nil field is simply skipped.
+type Hooks struct {
+	// OnCalleeAdded fires the first time a (to, sig) callee is seen,
+	// before any call is recorded against it.
+	OnCalleeAdded func(to, sig string)
+	// OnConflictRecorded fires when Add, AddWithEvidence, or ImportJSON
+	// records a conflict between a and b that wasn't already known.
+	OnConflictRecorded func(a, b string)
+	// OnScheduleBuilt fires once New (or NewWithContext) has finished
+	// building sched, immediately before it's returned to the caller.
+	OnScheduleBuilt func(sched *Schedule)
+	// OnDeferred fires once per message New pushes into Schedule.Deferred
+	// instead of a generation.
+	OnDeferred func(m *Message)
+}
+
+// SetHooks installs h, replacing any previously set Hooks. Passing the
+// zero Hooks{} disables all hooks.
+func (s *Scheduler) SetHooks(h Hooks) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = h
+}
+
+func (s *Scheduler) fireCalleeAdded(to, sig string) {
+	if s.hooks.OnCalleeAdded != nil {
+		s.hooks.OnCalleeAdded(to, sig)
+	}
+}
+
+func (s *Scheduler) fireConflictRecorded(a, b string) {
+	if s.hooks.OnConflictRecorded != nil {
+		s.hooks.OnConflictRecorded(a, b)
+	}
+}
+
+func (s *Scheduler) fireScheduleBuilt(sched *Schedule) {
+	if s.hooks.OnScheduleBuilt != nil {
+		s.hooks.OnScheduleBuilt(sched)
+	}
+}
+
+func (s *Scheduler) fireDeferred(m *Message) {
+	if s.hooks.OnDeferred != nil {
+		s.hooks.OnDeferred(m)
+	}
+}
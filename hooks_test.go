@@ -0,0 +1,52 @@
+package scheduler
+
+import "testing"
+
+func TestOnCalleeAddedFiresOnlyForNewCallees(t *testing.T) {
+	s := NewScheduler()
+	var seen []string
+	s.SetHooks(Hooks{OnCalleeAdded: func(to, sig string) { seen = append(seen, to+":"+sig) }})
+
+	s.calleeFor("0xA", "f()")
+	s.calleeFor("0xA", "f()")
+	s.calleeFor("0xB", "g()")
+
+	if len(seen) != 2 {
+		t.Fatalf("expected OnCalleeAdded to fire once per distinct callee, got %v", seen)
+	}
+}
+
+func TestOnConflictRecordedFiresOnceForANewPair(t *testing.T) {
+	s := NewScheduler()
+	calls := 0
+	s.SetHooks(Hooks{OnConflictRecorded: func(a, b string) { calls++ }})
+
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	if calls != 1 {
+		t.Fatalf("expected OnConflictRecorded to fire once for a re-added pair, got %d", calls)
+	}
+}
+
+func TestOnScheduleBuiltAndOnDeferredFire(t *testing.T) {
+	s := NewScheduler()
+	s.SetDeferrable("0xA", "f()", true)
+	s.SetDeferThreshold(1)
+	s.calleeFor("0xA", "f()").Calls = 5
+
+	var built *Schedule
+	var deferredIDs []uint64
+	s.SetHooks(Hooks{
+		OnScheduleBuilt: func(sched *Schedule) { built = sched },
+		OnDeferred:      func(m *Message) { deferredIDs = append(deferredIDs, m.ID) },
+	})
+
+	sched := s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()"}})
+	if built != sched {
+		t.Fatal("expected OnScheduleBuilt to receive the returned Schedule")
+	}
+	if len(deferredIDs) != 1 || deferredIDs[0] != 1 {
+		t.Fatalf("expected OnDeferred to fire for message 1, got %v", deferredIDs)
+	}
+}
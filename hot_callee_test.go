@@ -0,0 +1,59 @@
+package scheduler
+
+import "testing"
+
+func TestNewDefersExcessInstancesOfAHotCallee(t *testing.T) {
+	s := NewScheduler()
+	s.SetHotCalleeThreshold(3)
+	s.SetHotCalleeCap(2)
+
+	// Warm up "0xA:f()" past the hot threshold without ever marking it
+	// Deferrable.
+	for i := 0; i < 3; i++ {
+		s.New([]*Message{{ID: uint64(100 + i), To: "0xA", Sig: "f()"}})
+	}
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xA", Sig: "f()"},
+		{ID: 3, To: "0xA", Sig: "f()"},
+	})
+
+	if len(sched.Deferred) != 1 {
+		t.Fatalf("expected exactly one instance deferred past the cap, got %v", sched.Deferred)
+	}
+
+	inBlock := 0
+	for _, gen := range sched.Generations {
+		inBlock += len(gen)
+	}
+	if inBlock != 2 {
+		t.Fatalf("expected only 2 instances scheduled in-block, got %d", inBlock)
+	}
+}
+
+func TestNewLeavesColdCalleesAloneUnderHotCalleeCap(t *testing.T) {
+	s := NewScheduler()
+	s.SetHotCalleeThreshold(100)
+	s.SetHotCalleeCap(1)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xA", Sig: "f()"},
+	})
+	if len(sched.Deferred) != 0 {
+		t.Fatalf("expected no deferral below the hot threshold, got %v", sched.Deferred)
+	}
+}
+
+func TestNewDisablesHotCalleeDetectionByDefault(t *testing.T) {
+	s := NewScheduler()
+	msgs := make([]*Message, 0, 10)
+	for i := 0; i < 10; i++ {
+		msgs = append(msgs, &Message{ID: uint64(i + 1), To: "0xA", Sig: "f()"})
+	}
+	sched := s.New(msgs)
+	if len(sched.Deferred) != 0 {
+		t.Fatalf("expected hot-callee deferral to be disabled by default, got %v", sched.Deferred)
+	}
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/arbitrator"
+
+// calleeIdentity is enough to look a Callee back up via calleeFor.
+type calleeIdentity struct {
+	to, sig string
+}
+
+// InferDeferrable marks callees as Deferrable when arbitration shows them
+// involved in a conflict and their messages only ever touch a handful of
+// distinct paths (<= maxPaths). A callee that always hits the same small
+// set of paths is the classic case that serializes an entire block, and
+// is a good candidate to push into a follow-up generation instead. It
+// returns how many callees were newly marked.
+func (s *Scheduler) InferDeferrable(msgs []*Message, conflicts []arbitrator.Conflict, maxPaths int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byID := make(map[uint64]*Message, len(msgs))
+	pathsByCallee := make(map[string]map[string]struct{})
+	identityByCallee := make(map[string]calleeIdentity)
+	for _, m := range msgs {
+		byID[m.ID] = m
+		key := s.messageKey(m)
+		identityByCallee[key] = calleeIdentity{to: m.To, sig: m.Sig}
+		set := pathsByCallee[key]
+		if set == nil {
+			set = make(map[string]struct{})
+			pathsByCallee[key] = set
+		}
+		for _, p := range m.ReadSet {
+			set[p] = struct{}{}
+		}
+		for _, p := range m.WriteSet {
+			set[p] = struct{}{}
+		}
+	}
+
+	conflicted := make(map[string]struct{})
+	for _, c := range conflicts {
+		if m, ok := byID[c.A]; ok {
+			conflicted[s.messageKey(m)] = struct{}{}
+		}
+		if m, ok := byID[c.B]; ok {
+			conflicted[s.messageKey(m)] = struct{}{}
+		}
+	}
+
+	marked := 0
+	for key := range conflicted {
+		paths := pathsByCallee[key]
+		if len(paths) == 0 || len(paths) > maxPaths {
+			continue
+		}
+		identity := identityByCallee[key]
+		c := s.calleeFor(identity.to, identity.sig)
+		if !c.Deferrable {
+			c.Deferrable = true
+			marked++
+		}
+	}
+	return marked
+}
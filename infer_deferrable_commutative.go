@@ -0,0 +1,71 @@
+package scheduler
+
+import "strings"
+
+// InferDeferrableFromCommutativity analyzes msgs' declared write sets and
+// marks a callee Deferrable once at least minConfidence of its examined
+// writes are, per message, either commutative (listed in
+// Message.CoinbaseWrites) or confined to paths under the callee's own
+// address — the classic accumulator-style callee (a reward pool, a
+// coinbase-like sweep) whose writes are safe to push to a follow-up
+// generation rather than treated as a genuine conflict. Every examined
+// callee's DeferConfidence is updated to the fraction observed,
+// regardless of whether it crosses minConfidence, so a caller can inspect
+// the score before deciding to raise or lower the threshold. It returns
+// how many callees were newly marked Deferrable.
+func (s *Scheduler) InferDeferrableFromCommutativity(msgs []*Message, minConfidence float64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type stats struct {
+		to, sig            string
+		commutative, total int
+	}
+	byCallee := make(map[string]*stats)
+
+	for _, m := range msgs {
+		if len(m.WriteSet) == 0 {
+			continue
+		}
+		key := s.messageKey(m)
+		st := byCallee[key]
+		if st == nil {
+			st = &stats{to: m.To, sig: m.Sig}
+			byCallee[key] = st
+		}
+		st.total++
+		if messageWritesOnlyCommutativeOrOwnPaths(m, s.normalize(m.To)) {
+			st.commutative++
+		}
+	}
+
+	marked := 0
+	for _, st := range byCallee {
+		c := s.calleeFor(st.to, st.sig)
+		c.DeferConfidence = float64(st.commutative) / float64(st.total)
+		if c.DeferConfidence >= minConfidence && !c.Deferrable {
+			c.Deferrable = true
+			marked++
+		}
+	}
+	return marked
+}
+
+// messageWritesOnlyCommutativeOrOwnPaths reports whether every path m
+// writes is either declared commutative or falls under ownAddr.
+func messageWritesOnlyCommutativeOrOwnPaths(m *Message, ownAddr string) bool {
+	commutative := make(map[string]struct{}, len(m.CoinbaseWrites))
+	for _, p := range m.CoinbaseWrites {
+		commutative[p] = struct{}{}
+	}
+	for _, p := range m.WriteSet {
+		if _, ok := commutative[p]; ok {
+			continue
+		}
+		if strings.HasPrefix(p, ownAddr) {
+			continue
+		}
+		return false
+	}
+	return true
+}
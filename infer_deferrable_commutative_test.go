@@ -0,0 +1,59 @@
+package scheduler
+
+import "testing"
+
+func TestInferDeferrableFromCommutativityMarksAccumulatorCallees(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xPool", Sig: "credit()", CoinbaseWrites: []string{"0xpool/total"}, WriteSet: []string{"0xpool/total"}},
+		{ID: 2, To: "0xPool", Sig: "credit()", CoinbaseWrites: []string{"0xpool/total"}, WriteSet: []string{"0xpool/total"}},
+	}
+
+	marked := s.InferDeferrableFromCommutativity(msgs, 1.0)
+	if marked != 1 {
+		t.Fatalf("expected 1 callee marked, got %d", marked)
+	}
+	c, ok := s.calleeDict.Get(calleeKey("0xpool", "credit()"))
+	if !ok || !c.Deferrable {
+		t.Fatalf("expected credit() to be marked Deferrable, got %+v ok=%v", c, ok)
+	}
+	if c.DeferConfidence != 1.0 {
+		t.Fatalf("expected confidence 1.0, got %v", c.DeferConfidence)
+	}
+}
+
+func TestInferDeferrableFromCommutativityLeavesNonCommutativeCalleesAlone(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "transfer()", WriteSet: []string{"0xB/balance"}},
+	}
+
+	marked := s.InferDeferrableFromCommutativity(msgs, 1.0)
+	if marked != 0 {
+		t.Fatalf("expected no callees marked, got %d", marked)
+	}
+	c, ok := s.calleeDict.Get(calleeKey("0xa", "transfer()"))
+	if !ok || c.Deferrable {
+		t.Fatalf("expected transfer() to remain non-deferrable, got %+v ok=%v", c, ok)
+	}
+	if c.DeferConfidence != 0 {
+		t.Fatalf("expected confidence 0, got %v", c.DeferConfidence)
+	}
+}
+
+func TestInferDeferrableFromCommutativityRespectsThreshold(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xPool", Sig: "credit()", CoinbaseWrites: []string{"0xpool/total"}, WriteSet: []string{"0xpool/total"}},
+		{ID: 2, To: "0xPool", Sig: "credit()", WriteSet: []string{"0xother/balance"}},
+	}
+
+	marked := s.InferDeferrableFromCommutativity(msgs, 0.9)
+	if marked != 0 {
+		t.Fatalf("expected 0.5 confidence to fall short of a 0.9 threshold, got %d marked", marked)
+	}
+	c, _ := s.calleeDict.Get(calleeKey("0xpool", "credit()"))
+	if c.DeferConfidence != 0.5 {
+		t.Fatalf("expected confidence 0.5, got %v", c.DeferConfidence)
+	}
+}
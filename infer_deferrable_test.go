@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+func TestInferDeferrableMarksNarrowConflictedCallee(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "bump()", WriteSet: []string{"p"}},
+		{ID: 2, To: "0xB", Sig: "other()", WriteSet: []string{"p"}},
+	}
+	conflicts := []arbitrator.Conflict{{A: 1, B: 2, Path: "p", Reason: arbitrator.ReasonWriteWrite}}
+
+	marked := s.InferDeferrable(msgs, conflicts, 1)
+	if marked != 2 {
+		t.Fatalf("expected both narrow callees to be marked deferrable, got %d", marked)
+	}
+	if !s.calleeFor("0xA", "bump()").Deferrable {
+		t.Fatal("expected 0xA:bump() to be marked deferrable")
+	}
+}
+
+func TestInferDeferrableSkipsWideCallees(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "wide()", WriteSet: []string{"p1", "p2"}},
+	}
+	conflicts := []arbitrator.Conflict{{A: 1, B: 1, Path: "p1"}}
+
+	if marked := s.InferDeferrable(msgs, conflicts, 1); marked != 0 {
+		t.Fatalf("expected wide callee not to be marked, got %d", marked)
+	}
+}
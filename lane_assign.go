@@ -0,0 +1,77 @@
+package scheduler
+
+import "strings"
+
+// Assign splits each generation of sched into up to numExecutors lanes
+// and sets Message.Lane on every message accordingly, so an executor
+// pool knows which of its N worker threads should run each message
+// within a generation. Lane IDs are only meaningful within one
+// generation — two messages in different generations may share a Lane
+// ID and still run one after the other. A generation whose messages
+// carry gas estimates is balanced by GasLimit, assigning each message to
+// the currently lightest lane, and among lanes tied on gas prefers
+// whichever has seen fewer calls to the same callee so far (per
+// sched.CallCounts, when populated) so a callee with many calls in one
+// generation doesn't pile onto a single lane; a generation with no gas
+// data (every GasLimit is 0) falls back to a simple round-robin split by
+// count. numExecutors below 1 is treated as 1.
+//
+// The call-count tie-break assumes the default address.EVM
+// normalization; a Scheduler configured with a custom address.Normalizer
+// may not benefit from it (balancing still falls back to gas alone,
+// which remains correct, just not as evenly spread).
+func (sched *Schedule) Assign(numExecutors int) {
+	if numExecutors < 1 {
+		numExecutors = 1
+	}
+	for i, gen := range sched.Generations {
+		var counts map[string]uint64
+		if i < len(sched.CallCounts) {
+			counts = sched.CallCounts[i]
+		}
+		if generationGas(gen) > 0 {
+			assignByGas(gen, numExecutors, counts)
+		} else {
+			assignByCount(gen, numExecutors)
+		}
+	}
+}
+
+// assignByCount spreads gen round-robin across numExecutors lanes.
+func assignByCount(gen []*Message, numExecutors int) {
+	for i, m := range gen {
+		m.Lane = i % numExecutors
+	}
+}
+
+// assignByGas assigns each message in gen to the lane with the least
+// GasLimit accumulated so far, balancing estimated work across lanes
+// rather than raw message count. counts, when non-nil, breaks a gas tie
+// in favor of the lane with fewer calls so far to the same callee.
+func assignByGas(gen []*Message, numExecutors int, counts map[string]uint64) {
+	laneGas := make([]uint64, numExecutors)
+	var laneHits []map[string]uint64
+	if counts != nil {
+		laneHits = make([]map[string]uint64, numExecutors)
+		for i := range laneHits {
+			laneHits[i] = make(map[string]uint64)
+		}
+	}
+	for _, m := range gen {
+		key := calleeKey(strings.ToLower(m.To), m.Sig)
+		lane := 0
+		for i := 1; i < numExecutors; i++ {
+			switch {
+			case laneGas[i] < laneGas[lane]:
+				lane = i
+			case laneGas[i] == laneGas[lane] && laneHits != nil && counts[key] > 1 && laneHits[i][key] < laneHits[lane][key]:
+				lane = i
+			}
+		}
+		m.Lane = lane
+		laneGas[lane] += m.GasLimit
+		if laneHits != nil {
+			laneHits[lane][key]++
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import "testing"
+
+func TestAssignByCountRoundRobinsWithoutGasData(t *testing.T) {
+	sched := &Schedule{Generations: [][]*Message{{
+		{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4},
+	}}}
+	sched.Assign(2)
+
+	want := []int{0, 1, 0, 1}
+	for i, m := range sched.Generations[0] {
+		if m.Lane != want[i] {
+			t.Fatalf("message %d: expected lane %d, got %d", m.ID, want[i], m.Lane)
+		}
+	}
+}
+
+func TestAssignByGasBalancesAccumulatedWork(t *testing.T) {
+	sched := &Schedule{Generations: [][]*Message{{
+		{ID: 1, GasLimit: 100},
+		{ID: 2, GasLimit: 10},
+		{ID: 3, GasLimit: 10},
+	}}}
+	sched.Assign(2)
+
+	lanes := make(map[uint64]int)
+	for _, m := range sched.Generations[0] {
+		lanes[m.ID] = m.Lane
+	}
+	if lanes[1] == lanes[2] {
+		t.Fatalf("expected the heavy message to get its own lane, got %v", lanes)
+	}
+	if lanes[2] != lanes[3] {
+		t.Fatalf("expected the two light messages to share the lighter lane, got %v", lanes)
+	}
+}
+
+func TestAssignTreatsSubOneExecutorCountAsOne(t *testing.T) {
+	sched := &Schedule{Generations: [][]*Message{{{ID: 1}, {ID: 2}}}}
+	sched.Assign(0)
+
+	for _, m := range sched.Generations[0] {
+		if m.Lane != 0 {
+			t.Fatalf("expected every message in a single lane, got %d", m.Lane)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+// Learn resolves conflicts (as reported by Arbitrator.Detect) back to the
+// callees that produced them and records each one via AddWithEvidence,
+// closing the loop between arbitration and scheduling without callers
+// having to translate transaction IDs into callee keys by hand. msgs must
+// include every message the conflicts' A and B IDs refer to; a conflict
+// whose ID isn't found is reported but does not stop the rest from being
+// learned.
+//
+// Learn also records every msgs entry's ReadSet and WriteSet into its
+// callee's touched-path profile (see PathProfileOverlap), regardless of
+// whether that message appears in conflicts — msgs represents completed
+// executions, so its access sets are ground truth for what each callee
+// actually touches, not just predictions.
+func (s *Scheduler) Learn(conflicts []arbitrator.Conflict, msgs []*Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byID := make(map[uint64]*Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.ID] = m
+		s.recordPathProfileLocked(m)
+	}
+
+	var missing []uint64
+	for _, c := range conflicts {
+		a, okA := byID[c.A]
+		b, okB := byID[c.B]
+		if !okA {
+			missing = append(missing, c.A)
+		}
+		if !okB {
+			missing = append(missing, c.B)
+		}
+		if !okA || !okB {
+			continue
+		}
+		s.addWithEvidenceLocked(s.messageKey(a), s.messageKey(b), c)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("scheduler: %d conflict message IDs not found in msgs: %v", len(missing), missing)
+	}
+	return nil
+}
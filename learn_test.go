@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+func TestLearnRecordsConflictsFromArbitratorOutput(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+	conflicts := []arbitrator.Conflict{
+		{A: 1, B: 2, Path: "p", Reason: arbitrator.ReasonWriteWrite},
+	}
+
+	if err := s.Learn(conflicts, msgs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected Learn to record a conflict between the two callees")
+	}
+	evidence, ok := s.Evidence(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	if !ok || evidence.Path != "p" {
+		t.Fatalf("expected Learn to record the conflict as evidence, got %+v, %v", evidence, ok)
+	}
+}
+
+func TestLearnReportsUnresolvableMessageIDs(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{{ID: 1, To: "0xA", Sig: "f()"}}
+	conflicts := []arbitrator.Conflict{{A: 1, B: 99, Path: "p"}}
+
+	if err := s.Learn(conflicts, msgs); err == nil {
+		t.Fatal("expected an error for a conflict referencing an unknown message ID")
+	}
+}
+
+func TestLearnAppliesEveryResolvableConflictDespiteOneMissing(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+	conflicts := []arbitrator.Conflict{
+		{A: 1, B: 2, Path: "p"},
+		{A: 1, B: 99, Path: "q"},
+	}
+
+	err := s.Learn(conflicts, msgs)
+	if err == nil {
+		t.Fatal("expected an error for the unresolvable conflict")
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the resolvable conflict to still be recorded")
+	}
+}
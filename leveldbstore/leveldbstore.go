@@ -0,0 +1,128 @@
+// Package leveldbstore provides a LevelDB-backed scheduler.CalleeStore, so
+// long-lived conflict histories with millions of callee pairs can be
+// persisted to disk and updated one key at a time as new callees are
+// learned, rather than serializing and rewriting the full set of callees
+// on every save.
+package leveldbstore
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+
+	"github.com/arcology-network/scheduler"
+)
+
+// Store is a scheduler.CalleeStore backed by an on-disk LevelDB database.
+// Each Set writes a single key rather than rewriting the whole store, so
+// it stays cheap to update incrementally as a Scheduler learns new
+// callees block after block. Because entries are serialized to and from
+// bytes, Get returns a distinct *scheduler.Callee value on every call
+// rather than a shared pointer; callers that mutate the returned Callee
+// must Set it back to persist the change.
+type Store struct {
+	mu  sync.Mutex
+	db  *leveldb.DB
+	err error
+}
+
+var _ scheduler.CalleeStore = (*Store)(nil)
+
+// Open opens (creating if necessary) a LevelDB database at path and
+// returns a Store backed by it. The caller is responsible for calling
+// Close when done with it.
+func Open(path string) (*Store, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying LevelDB database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Err returns the most recent error encountered by Get, Set, ForEach, or
+// Len, since the scheduler.CalleeStore interface has no room for one.
+// It is not reset by later successful calls, so a non-nil Err may
+// describe an earlier call than the last one.
+func (s *Store) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Store) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// Get looks up key and decodes the Callee stored under it, if any.
+func (s *Store) Get(key string) (*scheduler.Callee, bool) {
+	raw, err := s.db.Get([]byte(key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, false
+	}
+	if err != nil {
+		s.setErr(err)
+		return nil, false
+	}
+	var c scheduler.Callee
+	if err := json.Unmarshal(raw, &c); err != nil {
+		s.setErr(err)
+		return nil, false
+	}
+	return &c, true
+}
+
+// Set encodes c and writes it under key, touching only that one key.
+func (s *Store) Set(key string, c *scheduler.Callee) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		s.setErr(err)
+		return
+	}
+	if err := s.db.Put([]byte(key), raw, nil); err != nil {
+		s.setErr(err)
+	}
+}
+
+// ForEach decodes and visits every entry currently in the database.
+func (s *Store) ForEach(fn func(key string, c *scheduler.Callee)) {
+	iter := s.db.NewIterator(nil, nil)
+	defer iter.Release()
+	s.walk(iter, fn)
+}
+
+func (s *Store) walk(iter iterator.Iterator, fn func(key string, c *scheduler.Callee)) {
+	for iter.Next() {
+		var c scheduler.Callee
+		if err := json.Unmarshal(iter.Value(), &c); err != nil {
+			s.setErr(err)
+			continue
+		}
+		fn(string(iter.Key()), &c)
+	}
+	if err := iter.Error(); err != nil {
+		s.setErr(err)
+	}
+}
+
+// Len returns the number of distinct keys currently stored.
+func (s *Store) Len() int {
+	iter := s.db.NewIterator(nil, nil)
+	defer iter.Release()
+	n := 0
+	for iter.Next() {
+		n++
+	}
+	if err := iter.Error(); err != nil {
+		s.setErr(err)
+	}
+	return n
+}
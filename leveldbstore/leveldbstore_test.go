@@ -0,0 +1,83 @@
+package leveldbstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/arcology-network/scheduler"
+)
+
+func open(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "callees"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSetThenGetRoundTripsByValue(t *testing.T) {
+	s := open(t)
+	s.Set("k", &scheduler.Callee{Address: "0xa", Signature: "f()", Calls: 3, Deferrable: true})
+
+	got, ok := s.Get("k")
+	if !ok {
+		t.Fatal("expected Get to find the key just Set")
+	}
+	if got.Address != "0xa" || got.Signature != "f()" || got.Calls != 3 || !got.Deferrable {
+		t.Fatalf("expected the decoded Callee to match what was Set, got %+v", got)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetMissingReturnsFalse(t *testing.T) {
+	s := open(t)
+	if _, ok := s.Get("missing"); ok {
+		t.Fatal("expected Get on an empty store to report not found")
+	}
+}
+
+func TestLenAndForEachTrackDistinctKeys(t *testing.T) {
+	s := open(t)
+	s.Set("a", &scheduler.Callee{Address: "0xa", Signature: "f()"})
+	s.Set("b", &scheduler.Callee{Address: "0xb", Signature: "g()"})
+	s.Set("a", &scheduler.Callee{Address: "0xa", Signature: "f()"}) // overwrite, not a new key
+
+	if n := s.Len(); n != 2 {
+		t.Fatalf("expected Len to count 2 distinct keys, got %d", n)
+	}
+
+	visits := make(map[string]int)
+	s.ForEach(func(key string, c *scheduler.Callee) {
+		visits[key]++
+	})
+	if len(visits) != 2 || visits["a"] != 1 || visits["b"] != 1 {
+		t.Fatalf("expected ForEach to visit each of 2 keys exactly once, got %v", visits)
+	}
+}
+
+func TestStoreSurvivesReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "callees")
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	s.Set("k", &scheduler.Callee{Address: "0xa", Signature: "f()", Calls: 5})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	got, ok := reopened.Get("k")
+	if !ok || got.Calls != 5 {
+		t.Fatalf("expected the reopened store to see the persisted entry, got %+v, %v", got, ok)
+	}
+}
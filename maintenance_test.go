@@ -0,0 +1,57 @@
+package scheduler
+
+import "testing"
+
+func TestNewReservesFinalGenerationForMaintenance(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaintenance("0xsys", "sweep()", true)
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10},
+		{ID: 2, To: "0xsys", Sig: "sweep()", GasLimit: 5},
+	}
+	sched := s.New(msgs)
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected user and maintenance messages in separate generations, got %v", sched.Generations)
+	}
+	last := sched.Generations[len(sched.Generations)-1]
+	if len(last) != 1 || last[0].ID != 2 {
+		t.Fatalf("expected maintenance message to land in the final generation, got %v", last)
+	}
+}
+
+func TestNewNeverDefersMaintenanceMessages(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaintenance("0xsys", "sweep()", true)
+	s.SetDeferrable("0xsys", "sweep()", true)
+	s.SetDeferThreshold(1)
+
+	// Call the maintenance callee once to cross the defer threshold.
+	s.New([]*Message{{ID: 1, To: "0xsys", Sig: "sweep()"}})
+
+	sched := s.New([]*Message{{ID: 2, To: "0xsys", Sig: "sweep()"}})
+	if len(sched.Deferred) != 0 {
+		t.Fatalf("expected maintenance message to never be deferred, got %v", sched.Deferred)
+	}
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 1 {
+		t.Fatalf("expected the maintenance message to still be scheduled, got %v", sched.Generations)
+	}
+}
+
+func TestCompactGenerationsDoesNotMergeMaintenanceGeneration(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaintenance("0xsys", "sweep()", true)
+
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()"}},
+			{{ID: 2, To: "0xsys", Sig: "sweep()"}},
+		},
+		GenerationGas: []uint64{0, 0},
+	}
+
+	compacted := s.compactGenerations(sched)
+	if len(compacted.Generations) != 2 {
+		t.Fatalf("expected the maintenance generation to stay separate, got %v", compacted.Generations)
+	}
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import "fmt"
+
+// sequentialKey formats a 20-byte address and 4-byte function selector as
+// the "0x<hex>:0x<hex>" callee key MarkSequential operates on. Messages
+// invoking a sequential-marked callee must set To and Sig to this same
+// hex form for the demotion to take effect — runtimes that key selectors
+// by raw bytes rather than a human-readable signature string should use
+// this encoding consistently rather than mixing it with the "f()"-style
+// Sig strings the rest of the package otherwise accepts.
+func sequentialKey(addr [20]byte, sig [4]byte) string {
+	return calleeKey(fmt.Sprintf("0x%x", addr), fmt.Sprintf("0x%x", sig))
+}
+
+// MarkSequential forces every message invoking the callee identified by
+// (addr, sig) to run alone in its own generation, conflicting with every
+// other message regardless of what arbitration or the learned conflict
+// dictionary say. It lets an operator demote a misbehaving or newly
+// suspect contract to sequential execution immediately — without waiting
+// for a corrected on-chain property transition (see ApplyProperties) or
+// restarting the node with a rebuilt conflict database. Passing
+// sequential=false lifts the demotion.
+func (s *Scheduler) MarkSequential(addr [20]byte, sig [4]byte, sequential bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := sequentialKey(addr, sig)
+	if sequential {
+		if s.sequential == nil {
+			s.sequential = make(map[string]struct{})
+		}
+		s.sequential[key] = struct{}{}
+		return
+	}
+	delete(s.sequential, key)
+}
+
+func (s *Scheduler) isSequential(calleeKey string) bool {
+	_, ok := s.sequential[calleeKey]
+	return ok
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import "testing"
+
+func TestMarkSequentialForcesCalleeAlone(t *testing.T) {
+	s := NewScheduler()
+	var addr [20]byte
+	addr[19] = 0xAB
+	var sig [4]byte
+	sig[0] = 0x01
+
+	to := "0x00000000000000000000000000000000000000ab"
+	selector := "0x01000000"
+
+	s.MarkSequential(addr, sig, true)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: to, Sig: selector},
+		{ID: 2, To: "0xB", Sig: "g()"},
+		{ID: 3, To: "0xC", Sig: "h()"},
+	})
+
+	for _, gen := range sched.Generations {
+		hasSequential := false
+		for _, m := range gen {
+			if m.ID == 1 {
+				hasSequential = true
+			}
+		}
+		if hasSequential && len(gen) > 1 {
+			t.Fatalf("expected the sequential callee's message to run alone, got %v", gen)
+		}
+	}
+}
+
+func TestMarkSequentialFalseLiftsTheDemotion(t *testing.T) {
+	s := NewScheduler()
+	var addr [20]byte
+	var sig [4]byte
+
+	s.MarkSequential(addr, sig, true)
+	s.MarkSequential(addr, sig, false)
+
+	to := "0x0000000000000000000000000000000000000000"
+	selector := "0x00000000"
+	sched := s.New([]*Message{
+		{ID: 1, To: to, Sig: selector},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected the demotion to be lifted and both messages packed together, got %v", sched.Generations)
+	}
+}
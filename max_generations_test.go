@@ -0,0 +1,38 @@
+package scheduler
+
+import "testing"
+
+func TestMaxGenerationsOverflowsIntoSequentialTail(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerations(2)
+	// A fully connected conflict chain forces every message into its own
+	// generation absent the cap.
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xc", "h()"))
+	s.Add(calleeKey("0xb", "g()"), calleeKey("0xc", "h()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+		{ID: 3, To: "0xC", Sig: "h()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected exactly 2 generations under the cap, got %d", len(sched.Generations))
+	}
+	if len(sched.SequentialTail) != 1 || sched.SequentialTail[0].ID != 3 {
+		t.Fatalf("expected message 3 pushed into the sequential tail, got %v", sched.SequentialTail)
+	}
+}
+
+func TestMaxGenerationsZeroLeavesGenerationCountUnbounded(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 2 || sched.SequentialTail != nil {
+		t.Fatalf("expected no cap in effect, got %d generations and tail %v", len(sched.Generations), sched.SequentialTail)
+	}
+}
@@ -0,0 +1,19 @@
+package scheduler
+
+// Merge unions other's learned callee statistics, flags, and conflict
+// graph into s, so conflict knowledge learned independently by different
+// validator nodes or shards can be combined offline. Callees are keyed by
+// address and signature rather than index, so unlike a positional
+// mapping, no re-mapping step is needed for the merge to line up
+// correctly; a callee or conflict pair known to both s and other is
+// reconciled the same way ImportJSON reconciles a re-imported record.
+// other is left unmodified.
+func (s *Scheduler) Merge(other *Scheduler) int {
+	other.mu.Lock()
+	db := other.exportLocked()
+	other.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.importLocked(db)
+}
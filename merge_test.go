@@ -0,0 +1,37 @@
+package scheduler
+
+import "testing"
+
+func TestMergeUnionsConflictsFromAnotherScheduler(t *testing.T) {
+	a := NewScheduler()
+	a.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	b := NewScheduler()
+	b.Add(calleeKey("0xc", "h()"), calleeKey("0xd", "i()"))
+
+	n := a.Merge(b)
+	if n == 0 {
+		t.Fatal("expected Merge to report at least one imported record")
+	}
+	if !a.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected a's own conflict to survive the merge")
+	}
+	if !a.conflicting(calleeKey("0xc", "h()"), calleeKey("0xd", "i()")) {
+		t.Fatal("expected b's conflict to be merged into a")
+	}
+}
+
+func TestMergeLeavesTheOtherSchedulerUnmodified(t *testing.T) {
+	a := NewScheduler()
+	b := NewScheduler()
+	b.Add(calleeKey("0xc", "h()"), calleeKey("0xd", "i()"))
+
+	a.Merge(b)
+
+	if a.conflicting(calleeKey("0xc", "h()"), calleeKey("0xd", "i()")) == false {
+		t.Fatal("expected the merged conflict to be present in a")
+	}
+	if !b.conflicting(calleeKey("0xc", "h()"), calleeKey("0xd", "i()")) {
+		t.Fatal("expected b's own conflict to remain untouched by the merge")
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+// Message is a single transaction submitted for scheduling. ReadSet and
+// WriteSet are the state paths (e.g. "<address>/<container>/<key>") the
+// transaction is known or predicted to touch.
+type Message struct {
+	ID       uint64
+	From     string
+	To       string
+	Sig      string
+	Nonce    uint64
+	GasLimit uint64
+	GasPrice uint64
+	ReadSet  []string
+	WriteSet []string
+	// CoinbaseWrites holds the fee-recipient balance paths this message
+	// credits. They are modeled as commutative in the arbitrator: every
+	// message in a block adds to the same balance, so crediting it does
+	// not by itself force the messages to serialize.
+	CoinbaseWrites []string
+	// Lane is the executor thread this message was assigned to within its
+	// generation, set by Schedule.Assign. It is 0 (unassigned) until
+	// Assign runs.
+	Lane int
+}
+
+// calleeKey identifies the (address, function signature) pair a Message
+// invokes, used to key learned scheduling statistics. to is assumed to
+// already be normalized by the caller (see address.Normalizer).
+func calleeKey(to, sig string) string {
+	return to + ":" + sig
+}
@@ -0,0 +1,44 @@
+// Package metrics defines the observability hook the scheduler and
+// arbitrator report scheduling and arbitration statistics through,
+// leaving the choice of backend (Prometheus, StatsD, plain logging, or
+// nothing at all) to the caller.
+package metrics
+
+import "time"
+
+// Observer receives scheduling and arbitration statistics as they
+// happen. Implementations must be safe for concurrent use, since New and
+// Detect may be called from multiple goroutines (see workerpool and
+// Arbitrator's streaming Detect support). All methods must return
+// promptly; an Observer that blocks stalls scheduling.
+type Observer interface {
+	// ObserveCalleeCount reports the number of distinct callees a
+	// Scheduler currently knows about.
+	ObserveCalleeCount(n int)
+	// ObserveGenerations reports how many generations a New call packed
+	// a block into.
+	ObserveGenerations(n int)
+	// ObserveParallelWidth reports the size of the largest generation in
+	// a schedule, i.e. how wide the block can run.
+	ObserveParallelWidth(n int)
+	// ObserveDeferred reports how many messages a New call deferred.
+	ObserveDeferred(n int)
+	// ObserveNewLatency reports how long a New call took.
+	ObserveNewLatency(d time.Duration)
+	// ObserveConflicts reports how many conflicts a Detect call found.
+	ObserveConflicts(n int)
+	// ObserveDetectLatency reports how long a Detect call took.
+	ObserveDetectLatency(d time.Duration)
+}
+
+// Noop is an Observer that discards everything it's given. It is the
+// zero-cost default for callers that never install an Observer.
+type Noop struct{}
+
+func (Noop) ObserveCalleeCount(int)             {}
+func (Noop) ObserveGenerations(int)             {}
+func (Noop) ObserveParallelWidth(int)           {}
+func (Noop) ObserveDeferred(int)                {}
+func (Noop) ObserveNewLatency(time.Duration)    {}
+func (Noop) ObserveConflicts(int)               {}
+func (Noop) ObserveDetectLatency(time.Duration) {}
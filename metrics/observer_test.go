@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNoopDiscardsEverything(t *testing.T) {
+	// Noop must satisfy Observer and never panic regardless of what it's
+	// given; there's nothing else to assert.
+	var o Observer = Noop{}
+	o.ObserveCalleeCount(10)
+	o.ObserveGenerations(3)
+	o.ObserveParallelWidth(5)
+	o.ObserveDeferred(1)
+	o.ObserveConflicts(2)
+	o.ObserveNewLatency(time.Millisecond)
+	o.ObserveDetectLatency(time.Millisecond)
+}
@@ -0,0 +1,88 @@
+// Package promobserver implements metrics.Observer with Prometheus
+// collectors, for callers that already expose a /metrics endpoint and
+// want scheduling and arbitration statistics folded into it.
+package promobserver
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Observer is a metrics.Observer backed by Prometheus gauges, counters,
+// and histograms. Register it with a prometheus.Registerer of the
+// caller's choosing before installing it via Scheduler.SetObserver or
+// Arbitrator.SetObserver.
+type Observer struct {
+	calleeCount   prometheus.Gauge
+	generations   prometheus.Histogram
+	parallelWidth prometheus.Histogram
+	deferred      prometheus.Histogram
+	newLatency    prometheus.Histogram
+	conflicts     prometheus.Histogram
+	detectLatency prometheus.Histogram
+}
+
+// New creates an Observer and registers its collectors with reg.
+func New(reg prometheus.Registerer) *Observer {
+	o := &Observer{
+		calleeCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "scheduler",
+			Name:      "callee_count",
+			Help:      "Number of distinct callees the scheduler has learned about.",
+		}),
+		generations: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "scheduler",
+			Name:      "generations",
+			Help:      "Number of generations a New call packed a block into.",
+			Buckets:   prometheus.LinearBuckets(1, 4, 10),
+		}),
+		parallelWidth: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "scheduler",
+			Name:      "parallel_width",
+			Help:      "Size of the largest generation in a schedule.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		deferred: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "scheduler",
+			Name:      "deferred_count",
+			Help:      "Number of messages a New call deferred out of the block.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		newLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "scheduler",
+			Name:      "new_latency_seconds",
+			Help:      "Latency of Scheduler.New calls.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		conflicts: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "arbitrator",
+			Name:      "conflicts_detected",
+			Help:      "Number of conflicts found by a single Detect call.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		detectLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "arbitrator",
+			Name:      "detect_latency_seconds",
+			Help:      "Latency of Arbitrator.Detect calls.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+	}
+	reg.MustRegister(
+		o.calleeCount, o.generations, o.parallelWidth, o.deferred,
+		o.newLatency, o.conflicts, o.detectLatency,
+	)
+	return o
+}
+
+func (o *Observer) ObserveCalleeCount(n int)   { o.calleeCount.Set(float64(n)) }
+func (o *Observer) ObserveGenerations(n int)   { o.generations.Observe(float64(n)) }
+func (o *Observer) ObserveParallelWidth(n int) { o.parallelWidth.Observe(float64(n)) }
+func (o *Observer) ObserveDeferred(n int)      { o.deferred.Observe(float64(n)) }
+func (o *Observer) ObserveNewLatency(d time.Duration) {
+	o.newLatency.Observe(d.Seconds())
+}
+func (o *Observer) ObserveConflicts(n int) { o.conflicts.Observe(float64(n)) }
+func (o *Observer) ObserveDetectLatency(d time.Duration) {
+	o.detectLatency.Observe(d.Seconds())
+}
@@ -0,0 +1,39 @@
+package promobserver
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestObserveCalleeCountSetsGauge(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	o := New(reg)
+
+	o.ObserveCalleeCount(42)
+
+	var m dto.Metric
+	if err := o.calleeCount.Write(&m); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if m.GetGauge().GetValue() != 42 {
+		t.Fatalf("expected gauge value 42, got %v", m.GetGauge().GetValue())
+	}
+}
+
+func TestObserveGenerationsRecordsToHistogram(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	o := New(reg)
+
+	o.ObserveGenerations(3)
+	o.ObserveGenerations(5)
+
+	var m dto.Metric
+	if err := o.generations.Write(&m); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if m.GetHistogram().GetSampleCount() != 2 {
+		t.Fatalf("expected 2 samples, got %d", m.GetHistogram().GetSampleCount())
+	}
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import "sort"
+
+// enforceNonceOrder guarantees that, for every sender, its messages end
+// up in strictly increasing generation order matching ascending Nonce —
+// packGreedily and packByGraphColoring already refuse to put two
+// same-sender messages in the same generation (see messagesConflict), but
+// neither one reasons about nonce order across generations, so a
+// lower-nonce message could still land after a higher-nonce one from the
+// same sender. Any message that would violate the order is pulled out
+// and moved to a fresh generation appended at the end, where it cannot
+// conflict with anything; empty generations left behind are then dropped.
+// gasOf estimates a message's gas cost for keeping gas in sync with the
+// moves — the same estimator (see Scheduler.estimatedGas) used to build
+// gas in the first place.
+func enforceNonceOrder(gens [][]*Message, gas []uint64, gasOf func(*Message) uint64) ([][]*Message, []uint64) {
+	type placement struct {
+		genIdx int
+		m      *Message
+	}
+	bySender := make(map[string][]placement)
+	for gi, gen := range gens {
+		for _, m := range gen {
+			if m.From == "" {
+				continue
+			}
+			bySender[m.From] = append(bySender[m.From], placement{gi, m})
+		}
+	}
+
+	for _, placements := range bySender {
+		sort.SliceStable(placements, func(i, j int) bool {
+			return placements[i].m.Nonce < placements[j].m.Nonce
+		})
+		last := -1
+		for _, p := range placements {
+			if p.genIdx > last {
+				last = p.genIdx
+				continue
+			}
+			gens[p.genIdx] = removeMessage(gens[p.genIdx], p.m)
+			gas[p.genIdx] -= gasOf(p.m)
+			gens = append(gens, []*Message{p.m})
+			gas = append(gas, gasOf(p.m))
+			last = len(gens) - 1
+		}
+	}
+
+	return dropEmptyGenerations(gens, gas)
+}
+
+func removeMessage(gen []*Message, target *Message) []*Message {
+	out := make([]*Message, 0, len(gen))
+	for _, m := range gen {
+		if m != target {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func dropEmptyGenerations(gens [][]*Message, gas []uint64) ([][]*Message, []uint64) {
+	var outGens [][]*Message
+	var outGas []uint64
+	for i, gen := range gens {
+		if len(gen) == 0 {
+			continue
+		}
+		outGens = append(outGens, gen)
+		outGas = append(outGas, gas[i])
+	}
+	return outGens, outGas
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import "testing"
+
+func TestNewNeverPacksSameSenderMessagesTogether(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, From: "0xsender", To: "0xA", Sig: "f()", Nonce: 0},
+		{ID: 2, From: "0xsender", To: "0xB", Sig: "g()", Nonce: 1},
+	})
+	for _, gen := range sched.Generations {
+		if len(gen) > 1 {
+			t.Fatalf("expected same-sender messages to never share a generation, got %v", gen)
+		}
+	}
+}
+
+func TestNewKeepsSameSenderMessagesInNonceOrder(t *testing.T) {
+	s := NewScheduler()
+	// Higher gas price would normally schedule the higher-nonce message
+	// first; nonce order must win instead.
+	sched := s.New([]*Message{
+		{ID: 1, From: "0xsender", To: "0xA", Sig: "f()", Nonce: 5, GasPrice: 1},
+		{ID: 2, From: "0xsender", To: "0xB", Sig: "g()", Nonce: 6, GasPrice: 100},
+	})
+
+	genOf := func(id uint64) int {
+		for gi, gen := range sched.Generations {
+			for _, m := range gen {
+				if m.ID == id {
+					return gi
+				}
+			}
+		}
+		t.Fatalf("message %d missing from schedule", id)
+		return -1
+	}
+	if genOf(1) >= genOf(2) {
+		t.Fatalf("expected the lower-nonce message to land in an earlier generation, got gen(1)=%d gen(2)=%d", genOf(1), genOf(2))
+	}
+}
+
+func TestNewStillParallelizesAcrossDifferentSenders(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, From: "0xa", To: "0xA", Sig: "f()"},
+		{ID: 2, From: "0xb", To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected unrelated senders to be packed into one generation, got %v", sched.Generations)
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arcology-network/scheduler/metrics"
+)
+
+type recordingObserver struct {
+	calleeCount   int
+	generations   int
+	parallelWidth int
+	deferred      int
+	newLatency    time.Duration
+}
+
+func (r *recordingObserver) ObserveCalleeCount(n int)   { r.calleeCount = n }
+func (r *recordingObserver) ObserveGenerations(n int)   { r.generations = n }
+func (r *recordingObserver) ObserveParallelWidth(n int) { r.parallelWidth = n }
+func (r *recordingObserver) ObserveDeferred(n int)      { r.deferred = n }
+func (r *recordingObserver) ObserveNewLatency(d time.Duration) {
+	r.newLatency = d
+}
+func (r *recordingObserver) ObserveConflicts(int)               {}
+func (r *recordingObserver) ObserveDetectLatency(time.Duration) {}
+
+var _ metrics.Observer = (*recordingObserver)(nil)
+
+func TestNewReportsStatsToObserver(t *testing.T) {
+	s := NewScheduler()
+	obs := &recordingObserver{}
+	s.SetObserver(obs)
+
+	s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+
+	if obs.calleeCount != 2 {
+		t.Fatalf("expected 2 callees observed, got %d", obs.calleeCount)
+	}
+	if obs.generations != 1 || obs.parallelWidth != 2 {
+		t.Fatalf("expected 1 generation of width 2, got generations=%d width=%d", obs.generations, obs.parallelWidth)
+	}
+}
+
+func TestNewWithoutObserverDoesNotPanic(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()"}})
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import "testing"
+
+func TestOptimisticConflictRateParallelizesLowProbabilityPair(t *testing.T) {
+	s := NewScheduler()
+	s.SetOptimisticConflictRate(0.5)
+
+	// One conflict recorded against many uneventful calls to "a" keeps its
+	// probability well under the 0.5 threshold.
+	s.Add("0xa:f()", "0xb:g()")
+	for i := 0; i < 9; i++ {
+		s.New([]*Message{{ID: uint64(100 + i), To: "0xa", Sig: "f()"}})
+	}
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xa", Sig: "f()"},
+		{ID: 2, To: "0xb", Sig: "g()"},
+	})
+
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected the low-probability conflict to be optimistically parallelized, got %v", sched.Generations)
+	}
+
+	decisions := s.OptimisticDecisions()
+	if len(decisions) != 1 {
+		t.Fatalf("expected one recorded optimistic decision, got %v", decisions)
+	}
+}
+
+func TestOptimisticConflictRateStillBlocksHighProbabilityPair(t *testing.T) {
+	s := NewScheduler()
+	s.SetOptimisticConflictRate(0.5)
+	s.Add("0xa:f()", "0xb:g()")
+	s.New([]*Message{{ID: 100, To: "0xa", Sig: "f()"}})
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xa", Sig: "f()"},
+		{ID: 2, To: "0xb", Sig: "g()"},
+	})
+
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the high-probability conflict to still be kept apart, got %v", sched.Generations)
+	}
+	if len(s.OptimisticDecisions()) != 0 {
+		t.Fatalf("expected no optimistic decisions recorded for a pair above the threshold")
+	}
+}
+
+func TestOptimisticDecisionsResetEachNew(t *testing.T) {
+	s := NewScheduler()
+	s.SetOptimisticConflictRate(1)
+	s.Add("0xa:f()", "0xb:g()")
+	s.New([]*Message{{ID: 100, To: "0xa", Sig: "f()"}})
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}, {ID: 2, To: "0xb", Sig: "g()"}})
+
+	if len(s.OptimisticDecisions()) != 1 {
+		t.Fatalf("expected the first batch's optimistic decision to be recorded")
+	}
+
+	s.New([]*Message{{ID: 3, To: "0xc", Sig: "h()"}})
+	if len(s.OptimisticDecisions()) != 0 {
+		t.Fatalf("expected OptimisticDecisions to reset for a batch with no optimistic pairs")
+	}
+}
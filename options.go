@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"github.com/arcology-network/scheduler/address"
+	"github.com/arcology-network/scheduler/metrics"
+	"github.com/arcology-network/scheduler/workerpool"
+)
+
+// Option configures a Scheduler at construction time, for
+// NewSchedulerWithOptions.
+type Option func(*Scheduler)
+
+// WithCalleeStore backs the Scheduler with store instead of the default
+// in-memory map, equivalent to NewSchedulerWithStore.
+func WithCalleeStore(store CalleeStore) Option {
+	return func(s *Scheduler) { s.calleeDict = store }
+}
+
+// WithWorkerPool sets the worker pool the Scheduler's parallel sections
+// (currently CrossCheck) run on, equivalent to SetWorkerPool.
+func WithWorkerPool(p *workerpool.Pool) Option {
+	return func(s *Scheduler) { s.pool = p }
+}
+
+// WithObserver reports scheduling metrics to o, equivalent to
+// SetObserver.
+func WithObserver(o metrics.Observer) Option {
+	return func(s *Scheduler) { s.observer = o }
+}
+
+// WithAddressNormalizer overrides how callee addresses are canonicalized
+// before lookup, equivalent to SetAddressNormalizer.
+func WithAddressNormalizer(n address.Normalizer) Option {
+	return func(s *Scheduler) { s.normalizeAddr = n }
+}
+
+// WithStrategy selects New's packing algorithm, equivalent to
+// SetStrategy.
+func WithStrategy(strategy Strategy) Option {
+	return func(s *Scheduler) { s.strategy = strategy }
+}
+
+// WithDeferThreshold sets the Scheduler-wide deferral threshold,
+// equivalent to SetDeferThreshold.
+func WithDeferThreshold(threshold uint64) Option {
+	return func(s *Scheduler) { s.deferThreshold = threshold }
+}
+
+// WithDeferDepth sets the Scheduler-wide deferral depth, equivalent to
+// SetDeferDepth.
+func WithDeferDepth(depth int) Option {
+	return func(s *Scheduler) { s.deferDepth = depth }
+}
+
+// WithMaxGenerationSize caps messages per generation, equivalent to
+// SetMaxGenerationSize.
+func WithMaxGenerationSize(n int) Option {
+	return func(s *Scheduler) { s.maxGenSize = n }
+}
+
+// WithMaxGenerationGas caps combined gas per generation, equivalent to
+// SetMaxGenerationGas.
+func WithMaxGenerationGas(limit uint64) Option {
+	return func(s *Scheduler) { s.maxGenGas = limit }
+}
+
+// WithMaxGenerations caps how many generations New will produce before
+// overflowing into Schedule.SequentialTail, equivalent to
+// SetMaxGenerations.
+func WithMaxGenerations(n int) Option {
+	return func(s *Scheduler) { s.maxGenerations = n }
+}
+
+// WithHotCalleeThreshold sets how many prior calls a callee needs before
+// it is treated as hot, equivalent to SetHotCalleeThreshold.
+func WithHotCalleeThreshold(threshold uint64) Option {
+	return func(s *Scheduler) { s.hotThreshold = threshold }
+}
+
+// WithHotCalleeCap sets the cap on hot-callee instances packed per batch,
+// equivalent to SetHotCalleeCap.
+func WithHotCalleeCap(cap int) Option {
+	return func(s *Scheduler) { s.hotCap = cap }
+}
+
+// WithHooks installs lifecycle callbacks, equivalent to SetHooks.
+func WithHooks(h Hooks) Option {
+	return func(s *Scheduler) { s.hooks = h }
+}
+
+// WithOptimisticConflictRate sets the empirical conflict-probability
+// threshold below which New packs a learned-conflicting pair together
+// anyway, equivalent to SetOptimisticConflictRate.
+func WithOptimisticConflictRate(rate float64) Option {
+	return func(s *Scheduler) { s.optimisticRate = rate }
+}
+
+// NewSchedulerWithOptions builds a Scheduler the same way NewScheduler
+// does (a plain in-memory CalleeStore, StrategyGreedy, every cap
+// disabled) and then applies opts in order, so a persistent backend
+// (WithCalleeStore), parallelism (WithWorkerPool), deferral behavior
+// (WithDeferThreshold/WithDeferDepth), and the rest of the tunables above
+// can all be set in one call instead of a constructor plus a string of
+// Set* calls after it. There is no separate decay or logging option:
+// this Scheduler has no time-based decay of learned conflicts, and
+// reports operational data through the existing metrics.Observer
+// (WithObserver) rather than a logger. NewScheduler remains the
+// zero-configuration entry point; NewSchedulerWithOptions is additive,
+// for callers that would otherwise need several Set* calls right after
+// construction.
+func NewSchedulerWithOptions(opts ...Option) *Scheduler {
+	s := NewScheduler()
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import "testing"
+
+func TestNewSchedulerWithOptionsAppliesEachOption(t *testing.T) {
+	s := NewSchedulerWithOptions(
+		WithDeferThreshold(3),
+		WithDeferDepth(2),
+		WithMaxGenerationSize(5),
+		WithMaxGenerationGas(1000),
+		WithMaxGenerations(4),
+		WithStrategy(StrategyGraphColoring),
+	)
+	if s.deferThreshold != 3 || s.deferDepth != 2 || s.maxGenSize != 5 ||
+		s.maxGenGas != 1000 || s.maxGenerations != 4 || s.strategy != StrategyGraphColoring {
+		t.Fatalf("expected every option to be applied, got %+v", s)
+	}
+}
+
+func TestNewSchedulerWithOptionsDefaultsMatchNewScheduler(t *testing.T) {
+	s := NewSchedulerWithOptions()
+	if s.calleeDict == nil || s.conflicts == nil || s.conflictChain == nil {
+		t.Fatal("expected NewSchedulerWithOptions with no options to be usable like NewScheduler")
+	}
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected a plain NewSchedulerWithOptions() to behave like NewScheduler()")
+	}
+}
+
+func TestWithCalleeStoreBacksTheSchedulerWithTheGivenStore(t *testing.T) {
+	store := &syncCalleeStore{}
+	s := NewSchedulerWithOptions(WithCalleeStore(store))
+	if s.calleeDict != CalleeStore(store) {
+		t.Fatal("expected WithCalleeStore to install the given store")
+	}
+}
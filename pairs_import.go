@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Format selects the on-disk encoding ImportPairsFile expects.
+type Format int
+
+const (
+	FormatCSV Format = iota
+	FormatJSON
+)
+
+// PairRecord is one externally supplied conflict pair between two
+// callees. Weight is optional: ImportPairsFile parses it so input files
+// that carry a score column are accepted, but it is not stored or
+// consulted by scheduling, and ExportJSON always writes it as 0.
+type PairRecord struct {
+	AddressA  string
+	SelectorA string
+	AddressB  string
+	SelectorB string
+	Weight    float64
+}
+
+// ImportPairsFile loads conflict pairs from path in the given format and
+// records each one via Add, so researchers and operators can bootstrap a
+// Scheduler from manually curated or externally computed conflict sets
+// instead of waiting for it to learn them from live traffic. It returns
+// the number of pairs imported.
+func (s *Scheduler) ImportPairsFile(path string, format Format) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var records []PairRecord
+	switch format {
+	case FormatCSV:
+		records, err = decodePairsCSV(f)
+	case FormatJSON:
+		records, err = decodePairsJSON(f)
+	default:
+		return 0, fmt.Errorf("scheduler: unknown import format %d", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range records {
+		s.mu.Lock()
+		a := calleeKey(s.normalize(r.AddressA), r.SelectorA)
+		b := calleeKey(s.normalize(r.AddressB), r.SelectorB)
+		s.mu.Unlock()
+		s.Add(a, b)
+	}
+	return len(records), nil
+}
+
+// decodePairsCSV parses rows of (addressA, selectorA, addressB,
+// selectorB[, weight]).
+func decodePairsCSV(r io.Reader) ([]PairRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]PairRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 4 {
+			return nil, fmt.Errorf("scheduler: CSV row %v has fewer than 4 fields", row)
+		}
+		rec := PairRecord{AddressA: row[0], SelectorA: row[1], AddressB: row[2], SelectorB: row[3]}
+		if len(row) >= 5 && row[4] != "" {
+			w, err := strconv.ParseFloat(row[4], 64)
+			if err != nil {
+				return nil, fmt.Errorf("scheduler: invalid weight %q: %w", row[4], err)
+			}
+			rec.Weight = w
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func decodePairsJSON(r io.Reader) ([]PairRecord, error) {
+	var records []PairRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportPairsFileCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pairs.csv")
+	csv := "0xa,f(),0xb,g(),0.5\n0xa,f(),0xc,h()\n"
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScheduler()
+	n, err := s.ImportPairsFile(path, FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 pairs imported, got %d", n)
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected imported pair to be recorded as conflicting")
+	}
+}
+
+func TestImportPairsFileJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pairs.json")
+	json := `[{"AddressA":"0xa","SelectorA":"f()","AddressB":"0xb","SelectorB":"g()","Weight":1.5}]`
+	if err := os.WriteFile(path, []byte(json), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScheduler()
+	n, err := s.ImportPairsFile(path, FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 pair imported, got %d", n)
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected imported pair to be recorded as conflicting")
+	}
+}
+
+func TestImportPairsFileRejectsShortCSVRow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pairs.csv")
+	if err := os.WriteFile(path, []byte("0xa,f()\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScheduler()
+	if _, err := s.ImportPairsFile(path, FormatCSV); err == nil {
+		t.Fatal("expected an error for a short CSV row")
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import "context"
+
+// packByFootprint is the StrategyPathLevel packing algorithm: the same
+// generation-filling loop as packGreedily, but joining a generation is
+// decided by messagesConflictByFootprint instead of messagesConflict.
+func (s *Scheduler) packByFootprint(ctx context.Context, msgs []*Message) ([][]*Message, []uint64, []*Message, error) {
+	return s.packGreedilyWith(ctx, msgs, s.messagesConflictByFootprint)
+}
+
+// messagesConflictByFootprint reports whether a and b cannot share a
+// generation under StrategyPathLevel. Same-sender ordering and a
+// MarkSequential demotion are unconditional, the same as
+// messagesConflict, but the callee-pair conflict history and
+// AddPrefixRule are not consulted at all: the only conflict signal is
+// each message's storage footprint, taken from its declared access list
+// when present or its callee's learned path profile (see Learn,
+// PathProfileOverlap) otherwise. A pair with no footprint information on
+// either side is optimistically treated as non-conflicting.
+func (s *Scheduler) messagesConflictByFootprint(a, b *Message) bool {
+	if a.From != "" && a.From == b.From {
+		return true
+	}
+	keyA := s.messageKey(a)
+	keyB := s.messageKey(b)
+	if s.isSequential(keyA) || s.isSequential(keyB) {
+		return true
+	}
+	if keyA == keyB {
+		return true
+	}
+	if hasAccessList(a) && hasAccessList(b) {
+		return accessListsConflict(a, b)
+	}
+	return s.pathProfileOverlapLocked(keyA, keyB)
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import "testing"
+
+func TestPathLevelStrategyRunsDisjointFootprintsConcurrently(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyPathLevel)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xa", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"0xA/counterA"}},
+		{ID: 2, To: "0xA", Sig: "g()", WriteSet: []string{"0xA/counterB"}},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected disjoint footprints to share a generation despite a recorded pairwise conflict, got %+v", sched.Generations)
+	}
+}
+
+func TestPathLevelStrategyStillSeparatesOverlappingFootprints(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyPathLevel)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"0xA/counter"}},
+		{ID: 2, To: "0xA", Sig: "g()", WriteSet: []string{"0xA/counter"}},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected an overlapping footprint to force separate generations, got %+v", sched.Generations)
+	}
+}
+
+func TestPathLevelStrategyKeepsSameSenderOrdered(t *testing.T) {
+	s := NewScheduler()
+	s.SetStrategy(StrategyPathLevel)
+
+	sched := s.New([]*Message{
+		{ID: 1, From: "0xsender", To: "0xA", Sig: "f()"},
+		{ID: 2, From: "0xsender", To: "0xB", Sig: "g()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected same-sender messages to stay in separate generations, got %+v", sched.Generations)
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import "strings"
+
+// recordPathProfileLocked adds every path m reads or writes to its
+// callee's learned touched-path profile. Callers must hold s.mu.
+func (s *Scheduler) recordPathProfileLocked(m *Message) {
+	key := s.messageKey(m)
+	if s.pathProfiles == nil {
+		s.pathProfiles = make(map[string]map[string]struct{})
+	}
+	profile := s.pathProfiles[key]
+	if profile == nil {
+		profile = make(map[string]struct{})
+		s.pathProfiles[key] = profile
+	}
+	for _, p := range m.ReadSet {
+		profile[p] = struct{}{}
+	}
+	for _, p := range m.WriteSet {
+		profile[p] = struct{}{}
+	}
+}
+
+// pathProfileOverlapLocked reports whether a and b's learned touched-path
+// profiles share a common path, treating one path as covering another
+// when either is a prefix of the other (the same convention
+// AddPrefixRule/touchesPrefixFor use). It returns false until Learn has
+// recorded at least one execution for both callees. Callers must hold
+// s.mu.
+func (s *Scheduler) pathProfileOverlapLocked(a, b string) bool {
+	pa, ok := s.pathProfiles[a]
+	if !ok {
+		return false
+	}
+	pb, ok := s.pathProfiles[b]
+	if !ok {
+		return false
+	}
+	for p := range pa {
+		for q := range pb {
+			if p == q || strings.HasPrefix(p, q) || strings.HasPrefix(q, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PathProfileOverlap reports whether a and b's learned touched-path
+// profiles overlap, the same finer-grained signal messagesConflict
+// consults in addition to the pairwise callee conflict history. It is
+// exported for callers that want to inspect the learned profiles
+// directly rather than only see their effect on scheduling.
+func (s *Scheduler) PathProfileOverlap(a, b string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pathProfileOverlapLocked(a, b)
+}
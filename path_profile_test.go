@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+func TestLearnRecordsPathProfilesForEveryMessage(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"0xA/counter"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"0xA/counter/value"}},
+	}
+
+	if err := s.Learn(nil, msgs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !s.PathProfileOverlap(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected the two callees' touched-path profiles to overlap by prefix")
+	}
+}
+
+func TestPathProfileOverlapFalseWithoutRecordedExecutions(t *testing.T) {
+	s := NewScheduler()
+	if s.PathProfileOverlap(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatal("expected no overlap before any Learn call")
+	}
+}
+
+func TestPathProfileOverlapDrivesMessagesConflict(t *testing.T) {
+	s := NewScheduler()
+	executed := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"0xA/counter"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"0xA/counter/value"}},
+	}
+	if err := s.Learn([]arbitrator.Conflict{}, executed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// New batch, no declared access lists: without a learned path-profile
+	// signal there would be nothing to keep these two apart.
+	a := &Message{ID: 3, To: "0xA", Sig: "f()"}
+	b := &Message{ID: 4, To: "0xB", Sig: "g()"}
+	sched := s.New([]*Message{a, b})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the learned path-profile overlap to keep the two messages apart, got %d generations", len(sched.Generations))
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import "testing"
+
+func makeBatch() []*Message {
+	return []*Message{
+		{ID: 3, From: "0xa", To: "0xA", Sig: "f()", GasPrice: 10},
+		{ID: 1, From: "0xb", To: "0xB", Sig: "g()", GasPrice: 10},
+		{ID: 2, From: "0xc", To: "0xC", Sig: "h()", GasPrice: 20},
+	}
+}
+
+func TestNewProducesIdenticalSchedulesAcrossRuns(t *testing.T) {
+	first := NewScheduler().New(makeBatch())
+	second := NewScheduler().New(makeBatch())
+
+	if len(first.Generations) != len(second.Generations) {
+		t.Fatalf("expected identical generation counts, got %d and %d", len(first.Generations), len(second.Generations))
+	}
+	for gi := range first.Generations {
+		for i, m := range first.Generations[gi] {
+			if m.ID != second.Generations[gi][i].ID {
+				t.Fatalf("generation %d: expected identical ordering, got %v vs %v", gi, first.Generations[gi], second.Generations[gi])
+			}
+		}
+	}
+}
+
+func TestSetPriorityComparatorOverridesGasPriceOrdering(t *testing.T) {
+	s := NewScheduler()
+	s.SetPriorityComparator(func(a, b *Message) bool { return a.ID < b.ID })
+
+	ordered := s.orderByPriority(makeBatch())
+	if ordered[0].ID != 1 || ordered[1].ID != 2 || ordered[2].ID != 3 {
+		t.Fatalf("expected ascending ID order, got %v %v %v", ordered[0].ID, ordered[1].ID, ordered[2].ID)
+	}
+}
+
+func TestSetPriorityComparatorNilRestoresDefault(t *testing.T) {
+	s := NewScheduler()
+	s.SetPriorityComparator(func(a, b *Message) bool { return a.ID < b.ID })
+	s.SetPriorityComparator(nil)
+
+	ordered := s.orderByPriority(makeBatch())
+	if ordered[0].ID != 2 {
+		t.Fatalf("expected descending GasPrice order to be restored, got first ID %d", ordered[0].ID)
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import "container/heap"
+
+// messagePQ is a container/heap of messages ordered by descending
+// GasPrice, so New considers higher-priority messages for placement
+// first instead of processing the batch in arrival order.
+type messagePQ []*Message
+
+func (pq messagePQ) Len() int { return len(pq) }
+
+func (pq messagePQ) Less(i, j int) bool { return pq[i].GasPrice > pq[j].GasPrice }
+
+func (pq messagePQ) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *messagePQ) Push(x interface{}) { *pq = append(*pq, x.(*Message)) }
+
+func (pq *messagePQ) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	m := old[n-1]
+	*pq = old[:n-1]
+	return m
+}
+
+// byPriority returns msgs ordered by descending GasPrice.
+func byPriority(msgs []*Message) []*Message {
+	pq := make(messagePQ, len(msgs))
+	copy(pq, msgs)
+	heap.Init(&pq)
+
+	ordered := make([]*Message, 0, len(msgs))
+	for pq.Len() > 0 {
+		ordered = append(ordered, heap.Pop(&pq).(*Message))
+	}
+	return ordered
+}
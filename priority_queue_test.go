@@ -0,0 +1,15 @@
+package scheduler
+
+import "testing"
+
+func TestByPriorityOrdersByDescendingGasPrice(t *testing.T) {
+	msgs := []*Message{
+		{ID: 1, GasPrice: 5},
+		{ID: 2, GasPrice: 50},
+		{ID: 3, GasPrice: 20},
+	}
+	ordered := byPriority(msgs)
+	if ordered[0].ID != 2 || ordered[1].ID != 3 || ordered[2].ID != 1 {
+		t.Fatalf("expected descending GasPrice order, got %v %v %v", ordered[0].ID, ordered[1].ID, ordered[2].ID)
+	}
+}
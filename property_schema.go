@@ -0,0 +1,65 @@
+package scheduler
+
+import "fmt"
+
+// PropertyVersion identifies the encoding used for a callee's declared
+// concurrency properties (e.g. from a Solidity-side scheduler API), so
+// old encodings stay readable as the schema evolves.
+type PropertyVersion uint8
+
+// PropertyVersionV1 is the original encoding: a single byte of flags,
+// where bit 0 set means the callee should be treated as Deferrable.
+const PropertyVersionV1 PropertyVersion = 0
+
+// CalleeProperties is the decoded form of a callee's declared
+// concurrency properties, independent of how they were encoded.
+type CalleeProperties struct {
+	Deferrable bool
+}
+
+// PropertyParser decodes one version's raw property bytes into
+// CalleeProperties.
+type PropertyParser func(raw []byte) (CalleeProperties, error)
+
+var propertyParsers = map[PropertyVersion]PropertyParser{
+	PropertyVersionV1: parsePropertiesV1,
+}
+
+// RegisterPropertyParser installs a parser for a property schema version,
+// so future scheduler API encodings can be added without breaking nodes
+// that only know older versions, and older versions remain readable
+// alongside them.
+func RegisterPropertyParser(version PropertyVersion, parser PropertyParser) {
+	propertyParsers[version] = parser
+}
+
+// ParseProperties decodes raw using the parser registered for version. It
+// returns an error rather than guessing if version is unrecognized, so a
+// node that has never seen a newer schema fails loudly instead of
+// misreading it.
+func ParseProperties(version PropertyVersion, raw []byte) (CalleeProperties, error) {
+	parser, ok := propertyParsers[version]
+	if !ok {
+		return CalleeProperties{}, fmt.Errorf("scheduler: no property parser registered for version %d", version)
+	}
+	return parser(raw)
+}
+
+func parsePropertiesV1(raw []byte) (CalleeProperties, error) {
+	if len(raw) < 1 {
+		return CalleeProperties{}, fmt.Errorf("scheduler: v1 properties require at least 1 byte, got %d", len(raw))
+	}
+	return CalleeProperties{Deferrable: raw[0]&0x1 != 0}, nil
+}
+
+// ApplyProperties decodes raw using the schema for version and updates
+// the (to, sig) callee's flags accordingly, keeping property decoding
+// independent of how those flags get applied to a Callee.
+func (s *Scheduler) ApplyProperties(to, sig string, version PropertyVersion, raw []byte) error {
+	props, err := ParseProperties(version, raw)
+	if err != nil {
+		return err
+	}
+	s.SetDeferrable(to, sig, props.Deferrable)
+	return nil
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import "testing"
+
+func TestParsePropertiesV1DecodesDeferrableFlag(t *testing.T) {
+	props, err := ParseProperties(PropertyVersionV1, []byte{0x1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !props.Deferrable {
+		t.Fatal("expected bit 0 set to decode as Deferrable")
+	}
+}
+
+func TestParsePropertiesUnknownVersionErrors(t *testing.T) {
+	if _, err := ParseProperties(PropertyVersion(99), []byte{0x1}); err == nil {
+		t.Fatal("expected an error for an unregistered property version")
+	}
+}
+
+func TestRegisterPropertyParserAddsNewVersion(t *testing.T) {
+	const v2 PropertyVersion = 1
+	RegisterPropertyParser(v2, func(raw []byte) (CalleeProperties, error) {
+		return CalleeProperties{Deferrable: len(raw) > 0 && raw[0] == 0xff}, nil
+	})
+
+	props, err := ParseProperties(v2, []byte{0xff})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !props.Deferrable {
+		t.Fatal("expected the registered v2 parser to be used")
+	}
+}
+
+func TestApplyPropertiesUpdatesCallee(t *testing.T) {
+	s := NewScheduler()
+	if err := s.ApplyProperties("0xa", "f()", PropertyVersionV1, []byte{0x1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.calleeFor("0xa", "f()").Deferrable {
+		t.Fatal("expected ApplyProperties to mark the callee Deferrable")
+	}
+}
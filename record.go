@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Trace captures everything needed to reproduce and verify one call to
+// Scheduler.New later via Replay: a snapshot of the Scheduler's learned
+// conflict DB taken immediately before New ran, the messages given to
+// it, and the Schedule it produced. Recording alongside every block lets
+// a scheduling bug reported from production be reproduced exactly on a
+// different machine, instead of guessed at from logs.
+type Trace struct {
+	ConflictDB ConflictDB
+	Messages   []*Message
+	Schedule   *Schedule
+}
+
+// Record runs msgs through New the normal way and returns a Trace
+// capturing the pre-call conflict DB snapshot, msgs, and the resulting
+// Schedule. Write the Trace with WriteTrace to replay it later, possibly
+// after the Scheduler's own learned state has moved on.
+func (s *Scheduler) Record(msgs []*Message) *Trace {
+	s.mu.Lock()
+	db := s.exportLocked()
+	s.mu.Unlock()
+
+	sched := s.New(msgs)
+	return &Trace{ConflictDB: db, Messages: msgs, Schedule: sched}
+}
+
+// WriteTrace JSON-encodes tr to w.
+func WriteTrace(w io.Writer, tr *Trace) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tr)
+}
+
+// ReadTrace decodes a Trace previously written by WriteTrace from r.
+func ReadTrace(r io.Reader) (*Trace, error) {
+	var tr Trace
+	if err := json.NewDecoder(r).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+// Replay reconstructs a fresh Scheduler from tr's conflict DB snapshot,
+// re-runs tr.Messages through New, and reports whether the result
+// matches tr.Schedule exactly. Where Scheduler.CrossCheck checks one
+// schedule's generations against live arbitration, Replay checks a fresh
+// schedule against a previously recorded one — useful for
+// tracking down a scheduling divergence reported from production without
+// needing to reproduce the original block's live inputs. The returned
+// Schedule is always the freshly computed one; a non-nil error means it
+// diverged from tr.Schedule.
+func Replay(tr *Trace) (*Schedule, error) {
+	replay := NewScheduler()
+	replay.mu.Lock()
+	replay.importLocked(tr.ConflictDB)
+	replay.mu.Unlock()
+
+	got := replay.New(tr.Messages)
+	if !reflect.DeepEqual(got, tr.Schedule) {
+		return got, fmt.Errorf("scheduler: replay diverged from the recorded schedule")
+	}
+	return got, nil
+}
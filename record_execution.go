@@ -0,0 +1,56 @@
+package scheduler
+
+// RecordExecution updates the (to, sig) callee's AvgGas with an observed
+// gasUsed from an actual execution, and bumps its Calls counter. AvgGas
+// is a running mean — RecordExecution doesn't need the full execution
+// history to stay accurate, just the previous average and how many
+// executions contributed to it. Once at least one execution has been
+// recorded, New prefers AvgGas over a message's own declared GasLimit
+// when packing and reporting gas usage (see Schedule.GenerationGas).
+func (s *Scheduler) RecordExecution(to, sig string, gasUsed uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordExecutionLocked(to, sig, gasUsed)
+}
+
+// recordExecutionLocked is RecordExecution's body, callable from other
+// methods that already hold s.mu so they don't deadlock re-acquiring it.
+func (s *Scheduler) recordExecutionLocked(to, sig string, gasUsed uint64) {
+	c := s.calleeFor(to, sig)
+	c.Calls++
+	c.AvgGas = (c.AvgGas*(c.Calls-1) + gasUsed) / c.Calls
+}
+
+// estimatedGas returns m's learned AvgGas if its callee has recorded at
+// least one execution, falling back to m's own declared GasLimit
+// otherwise.
+func (s *Scheduler) estimatedGas(m *Message) uint64 {
+	if c, ok := s.calleeDict.Get(s.messageKey(m)); ok && c.AvgGas > 0 {
+		return c.AvgGas
+	}
+	return m.GasLimit
+}
+
+// estimatedGenerationGas sums estimatedGas across every message in gen.
+func (s *Scheduler) estimatedGenerationGas(gen []*Message) uint64 {
+	var total uint64
+	for _, m := range gen {
+		total += s.estimatedGas(m)
+	}
+	return total
+}
+
+// computeCallCounts builds Schedule.CallCounts for gens: one map per
+// generation, from calleeKey to how many of that generation's messages
+// invoke it.
+func (s *Scheduler) computeCallCounts(gens [][]*Message) []map[string]uint64 {
+	counts := make([]map[string]uint64, len(gens))
+	for i, gen := range gens {
+		c := make(map[string]uint64, len(gen))
+		for _, m := range gen {
+			c[s.messageKey(m)]++
+		}
+		counts[i] = c
+	}
+	return counts
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import "testing"
+
+func TestRecordExecutionMaintainsRunningAverage(t *testing.T) {
+	s := NewScheduler()
+	s.RecordExecution("0xA", "f()", 100)
+	s.RecordExecution("0xA", "f()", 200)
+
+	c, ok := s.calleeDict.Get(calleeKey("0xa", "f()"))
+	if !ok {
+		t.Fatal("expected the callee to be recorded")
+	}
+	if c.AvgGas != 150 {
+		t.Fatalf("expected AvgGas 150, got %d", c.AvgGas)
+	}
+	if c.Calls != 2 {
+		t.Fatalf("expected Calls 2, got %d", c.Calls)
+	}
+}
+
+func TestNewPrefersLearnedAvgGasOverDeclaredGasLimit(t *testing.T) {
+	s := NewScheduler()
+	s.RecordExecution("0xA", "f()", 500)
+
+	sched := s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()", GasLimit: 1}})
+	if sched.GenerationGas[0] != 500 {
+		t.Fatalf("expected GenerationGas to use learned AvgGas, got %d", sched.GenerationGas[0])
+	}
+}
+
+func TestNewFallsBackToGasLimitWithoutRecordedExecutions(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()", GasLimit: 42}})
+	if sched.GenerationGas[0] != 42 {
+		t.Fatalf("expected GenerationGas to fall back to GasLimit, got %d", sched.GenerationGas[0])
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReplayReproducesTheRecordedSchedule(t *testing.T) {
+	s := NewScheduler()
+	s.Add("0xa:f()", "0xb:g()")
+	s.New([]*Message{{ID: 100, To: "0xa", Sig: "f()"}})
+
+	tr := s.Record([]*Message{
+		{ID: 1, To: "0xa", Sig: "f()"},
+		{ID: 2, To: "0xb", Sig: "g()"},
+		{ID: 3, To: "0xc", Sig: "h()"},
+	})
+
+	got, err := Replay(tr)
+	if err != nil {
+		t.Fatalf("unexpected replay divergence: %v", err)
+	}
+	if len(got.Generations) != len(tr.Schedule.Generations) {
+		t.Fatalf("expected replay to reproduce the same generation count, got %d want %d",
+			len(got.Generations), len(tr.Schedule.Generations))
+	}
+}
+
+func TestReplayDetectsDivergenceFromATamperedTrace(t *testing.T) {
+	s := NewScheduler()
+	tr := s.Record([]*Message{{ID: 1, To: "0xa", Sig: "f()"}, {ID: 2, To: "0xb", Sig: "g()"}})
+
+	// Tamper with the recorded schedule so the freshly computed one can no
+	// longer match it.
+	tr.Schedule.Generations = [][]*Message{{{ID: 1}}, {{ID: 2}}}
+
+	if _, err := Replay(tr); err == nil {
+		t.Fatalf("expected Replay to report a divergence against the tampered trace")
+	}
+}
+
+func TestWriteTraceReadTraceRoundTrip(t *testing.T) {
+	s := NewScheduler()
+	s.Add("0xa:f()", "0xb:g()")
+	tr := s.Record([]*Message{{ID: 1, To: "0xa", Sig: "f()"}, {ID: 2, To: "0xb", Sig: "g()"}})
+
+	var buf bytes.Buffer
+	if err := WriteTrace(&buf, tr); err != nil {
+		t.Fatalf("WriteTrace failed: %v", err)
+	}
+
+	got, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatalf("ReadTrace failed: %v", err)
+	}
+
+	if _, err := Replay(got); err != nil {
+		t.Fatalf("expected the round-tripped trace to still replay cleanly, got %v", err)
+	}
+}
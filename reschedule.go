@@ -0,0 +1,42 @@
+package scheduler
+
+// Reschedule builds a follow-up mini-schedule for just the messages
+// named in failedTxIDs — those the arbitrator flagged as conflicted or an
+// executor reported as failed — in their original relative order within
+// sched, so a caller doesn't have to strip them out of the batch and
+// rebuild it by hand. It's a Scheduler method rather than a Schedule one,
+// the same as Stats and DAG, since respecting learned conflicts needs the
+// Scheduler's own state, not just sched's shape. Under the hood it's
+// just New given the flagged messages in order, so the follow-up
+// schedule respects every conflict signal New already uses (learned
+// callee history, prefix rules, declared access lists, and so on) rather
+// than some separate, weaker notion of conflict. IDs not found anywhere
+// in sched are silently skipped.
+func (s *Scheduler) Reschedule(sched *Schedule, failedTxIDs []uint64) *Schedule {
+	want := make(map[uint64]struct{}, len(failedTxIDs))
+	for _, id := range failedTxIDs {
+		want[id] = struct{}{}
+	}
+
+	var msgs []*Message
+	seen := make(map[uint64]struct{}, len(failedTxIDs))
+	collect := func(list []*Message) {
+		for _, m := range list {
+			if _, ok := want[m.ID]; !ok {
+				continue
+			}
+			if _, dup := seen[m.ID]; dup {
+				continue
+			}
+			seen[m.ID] = struct{}{}
+			msgs = append(msgs, m)
+		}
+	}
+	for _, gen := range sched.Generations {
+		collect(gen)
+	}
+	collect(sched.SequentialTail)
+	collect(sched.Deferred)
+
+	return s.New(msgs)
+}
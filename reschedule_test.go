@@ -0,0 +1,76 @@
+package scheduler
+
+import "testing"
+
+func TestRescheduleBuildsAMiniScheduleForJustTheFlaggedTransactions(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+		{ID: 3, To: "0xC", Sig: "h()", ReadSet: []string{"c"}},
+	})
+
+	follow := s.Reschedule(sched, []uint64{3, 1})
+
+	total := 0
+	seen := make(map[uint64]bool)
+	for _, gen := range follow.Generations {
+		for _, m := range gen {
+			total++
+			seen[m.ID] = true
+		}
+	}
+	if total != 2 || !seen[1] || !seen[3] {
+		t.Fatalf("expected only messages 1 and 3 in the follow-up schedule, got %+v", follow.Generations)
+	}
+}
+
+func TestReschedulePreservesOriginalRelativeOrderRegardlessOfArgumentOrder(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerations(1)
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	})
+	if len(sched.SequentialTail) != 1 {
+		t.Fatalf("expected message 2 forced into the sequential tail, got %+v", sched.SequentialTail)
+	}
+
+	follow := s.Reschedule(sched, []uint64{2, 1})
+
+	var order []uint64
+	for _, gen := range follow.Generations {
+		for _, m := range gen {
+			order = append(order, m.ID)
+		}
+	}
+	order = append(order, idsOf(follow.SequentialTail)...)
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Fatalf("expected message 1 ahead of message 2 (their original order), got %v", order)
+	}
+}
+
+func idsOf(msgs []*Message) []uint64 {
+	ids := make([]uint64, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+func TestRescheduleSkipsUnknownIDs(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+	})
+
+	follow := s.Reschedule(sched, []uint64{1, 999})
+	total := 0
+	for _, gen := range follow.Generations {
+		total += len(gen)
+	}
+	if total != 1 {
+		t.Fatalf("expected exactly the one known message rescheduled, got %d", total)
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+import "testing"
+
+func TestRollbackHintsRecordOptimisticallyPairedMessages(t *testing.T) {
+	s := NewScheduler()
+	s.SetOptimisticConflictRate(0.5)
+	s.Add("0xa:f()", "0xb:g()")
+	for i := 0; i < 9; i++ {
+		s.New([]*Message{{ID: uint64(100 + i), To: "0xa", Sig: "f()"}})
+	}
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xa", Sig: "f()"},
+		{ID: 2, To: "0xb", Sig: "g()"},
+	})
+
+	if got := sched.RollbackHints[1]; len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected message 1 to hint at peer 2, got %v", got)
+	}
+	if got := sched.RollbackHints[2]; len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected message 2 to hint at peer 1, got %v", got)
+	}
+}
+
+func TestRollbackHintsNilWithoutOptimism(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}})
+	if sched.RollbackHints != nil {
+		t.Fatalf("expected no rollback hints without SetOptimisticConflictRate, got %v", sched.RollbackHints)
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+// Schedule is the output of a scheduling pass: messages grouped into
+// generations that can each execute concurrently, in order, followed by
+// any messages deferred to a later block.
+type Schedule struct {
+	Generations [][]*Message
+	Deferred    []*Message
+	// DeferredLevels buckets Deferred by how many follow-up blocks out a
+	// message was pushed (see Scheduler.SetDeferDepth): DeferredLevels[0]
+	// is scheduled into the very next block, DeferredLevels[1] the block
+	// after that, and so on. It exists so a hot contract with many
+	// deferred calls in one batch doesn't pile them all into a single
+	// follow-up generation — spreading them across levels lets each
+	// level's messages actually run in parallel with unrelated work in
+	// their own block instead of queueing up single-file. Deferred is
+	// always the concatenation of every level in order, for callers that
+	// don't care which level a message landed in. Nil when Deferred is
+	// empty.
+	DeferredLevels [][]*Message
+	// GenerationGas[i] is the estimated gas cost of Generations[i]: for
+	// each message, the learned AvgGas of its callee (see
+	// Scheduler.RecordExecution) if one has been recorded, falling back
+	// to the message's own GasLimit otherwise. This lets callers, and
+	// New's own gas-budget packing, work off real observed cost once a
+	// callee has executed at least once instead of the caller-declared
+	// worst case.
+	GenerationGas []uint64
+	// RollbackHints maps a message ID to the IDs of peer messages it shares
+	// a generation with despite a learned conflict, because
+	// Scheduler.SetOptimisticConflictRate judged the pair's conflict
+	// probability low enough to risk (see Scheduler.OptimisticDecisions
+	// for the same information keyed by callee instead of message). When
+	// the arbitrator later reports a real conflict inside one of these
+	// generations, an executor can consult RollbackHints to prioritize
+	// which of the colliding transactions to re-execute first instead of
+	// treating the whole generation as equally suspect. Nil when no
+	// optimistic pairs were packed together.
+	RollbackHints map[uint64][]uint64
+	// SequentialTail holds messages bumped out of Generations by
+	// Scheduler.SetMaxGenerations once the cap was reached, plus the
+	// messages NewWithContext had not placed when its context ended,
+	// ordered by the priority comparator (see SetPriorityComparator) while
+	// keeping each sender's nonce order and AddConstraint order; the
+	// message groups registered with AddBundle follow at the end. An
+	// executor runs SequentialTail one message at a time, after every
+	// generation, instead of the wide barrier-per-generation execution the
+	// rest of the schedule expects. Nil when none of these applied.
+	SequentialTail []*Message
+	// CallCounts[i] maps a calleeKey (see Scheduler.messageKey) to how
+	// many messages in Generations[i] invoke it, populated by New and
+	// NewWithContext. It exists for two things: exposing per-block
+	// contract activity statistics to callers without them re-deriving it
+	// from Generations by hand, and letting Assign spread a hot callee's
+	// calls evenly across lanes instead of letting gas balancing alone
+	// decide (see Assign). Always the same length as Generations.
+	CallCounts []map[string]uint64
+}
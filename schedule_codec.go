@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// EncodeJSON marshals sched to JSON, for debugging or shipping a schedule
+// to a process in another language.
+func (sched *Schedule) EncodeJSON() ([]byte, error) {
+	return json.Marshal(sched)
+}
+
+// DecodeScheduleJSON is the inverse of Schedule.EncodeJSON.
+func DecodeScheduleJSON(data []byte) (*Schedule, error) {
+	var sched Schedule
+	if err := json.Unmarshal(data, &sched); err != nil {
+		return nil, err
+	}
+	return &sched, nil
+}
+
+// EncodeBinary marshals sched with encoding/gob, a more compact format
+// than JSON for shipping a schedule to Go executor processes.
+func (sched *Schedule) EncodeBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sched); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeScheduleBinary is the inverse of Schedule.EncodeBinary.
+func DecodeScheduleBinary(data []byte) (*Schedule, error) {
+	var sched Schedule
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&sched); err != nil {
+		return nil, err
+	}
+	return &sched, nil
+}
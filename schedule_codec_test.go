@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func exampleSchedule() *Schedule {
+	return &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()", GasLimit: 10}},
+			{{ID: 2, To: "0xB", Sig: "g()", GasLimit: 20}},
+		},
+		Deferred:      []*Message{{ID: 3, To: "0xC", Sig: "h()"}},
+		GenerationGas: []uint64{10, 20},
+	}
+}
+
+func TestScheduleJSONRoundTrips(t *testing.T) {
+	sched := exampleSchedule()
+
+	data, err := sched.EncodeJSON()
+	if err != nil {
+		t.Fatalf("EncodeJSON: %v", err)
+	}
+	got, err := DecodeScheduleJSON(data)
+	if err != nil {
+		t.Fatalf("DecodeScheduleJSON: %v", err)
+	}
+	if !reflect.DeepEqual(sched, got) {
+		t.Fatalf("expected round-tripped schedule to match original, got %+v, want %+v", got, sched)
+	}
+}
+
+func TestScheduleBinaryRoundTrips(t *testing.T) {
+	sched := exampleSchedule()
+
+	data, err := sched.EncodeBinary()
+	if err != nil {
+		t.Fatalf("EncodeBinary: %v", err)
+	}
+	got, err := DecodeScheduleBinary(data)
+	if err != nil {
+		t.Fatalf("DecodeScheduleBinary: %v", err)
+	}
+	if !reflect.DeepEqual(sched, got) {
+		t.Fatalf("expected round-tripped schedule to match original, got %+v, want %+v", got, sched)
+	}
+}
+
+func TestDecodeScheduleJSONRejectsMalformedInput(t *testing.T) {
+	if _, err := DecodeScheduleJSON([]byte("not json")); err == nil {
+		t.Fatal("expected an error decoding malformed JSON")
+	}
+}
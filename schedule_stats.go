@@ -0,0 +1,98 @@
+package scheduler
+
+// ScheduleStats summarizes a Schedule's shape and quality, returned by
+// Scheduler.Stats.
+type ScheduleStats struct {
+	// Generations is len(sched.Generations).
+	Generations int
+	// Widths[i] is len(sched.Generations[i]) — how many messages can run
+	// concurrently in that generation.
+	Widths []int
+	// MaxWidth is the largest value in Widths, 0 for an empty schedule.
+	MaxWidth int
+	// SequentialTailLength is len(sched.SequentialTail): how many
+	// messages Scheduler.SetMaxGenerations bumped out of the generation
+	// structure to run one at a time.
+	SequentialTailLength int
+	// UnknownCalleeRatio is the fraction (0 to 1) of the schedule's
+	// distinct callees that have no learned execution history yet
+	// (AvgGas == 0, so estimatedGas fell back to each message's declared
+	// GasLimit). A high ratio means the speedup estimate below is mostly
+	// guesswork rather than learned fact.
+	UnknownCalleeRatio float64
+	// EstimatedSpeedup is total estimated work divided by the schedule's
+	// critical path: a generation contributes its slowest message's
+	// estimated gas to the critical path (everything else in it runs
+	// concurrently), and SequentialTail contributes the full sum of its
+	// messages' estimated gas, since they run one after another. 0 for an
+	// empty schedule.
+	EstimatedSpeedup float64
+}
+
+// Stats summarizes sched for monitoring scheduling quality per block —
+// how many generations it produced, how wide they are, how much of the
+// batch fell back to serial execution, how much of the estimate rests on
+// unlearned callees, and the estimated parallel speedup over running the
+// whole batch sequentially. It is a Scheduler method rather than a
+// Schedule one, since computing UnknownCalleeRatio and EstimatedSpeedup
+// needs the learned callee statistics Schedule itself doesn't carry.
+func (s *Scheduler) Stats(sched *Schedule) ScheduleStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	widths := make([]int, len(sched.Generations))
+	maxWidth := 0
+	var totalWork, criticalPath uint64
+	seen := make(map[string]struct{})
+	unknown := 0
+
+	for i, gen := range sched.Generations {
+		widths[i] = len(gen)
+		if len(gen) > maxWidth {
+			maxWidth = len(gen)
+		}
+		var slowest uint64
+		for _, m := range gen {
+			cost := s.estimatedGas(m)
+			totalWork += cost
+			if cost > slowest {
+				slowest = cost
+			}
+			s.trackUnknownCallee(m, seen, &unknown)
+		}
+		criticalPath += slowest
+	}
+	for _, m := range sched.SequentialTail {
+		cost := s.estimatedGas(m)
+		totalWork += cost
+		criticalPath += cost
+		s.trackUnknownCallee(m, seen, &unknown)
+	}
+
+	stats := ScheduleStats{
+		Generations:          len(sched.Generations),
+		Widths:               widths,
+		MaxWidth:             maxWidth,
+		SequentialTailLength: len(sched.SequentialTail),
+	}
+	if len(seen) > 0 {
+		stats.UnknownCalleeRatio = float64(unknown) / float64(len(seen))
+	}
+	if criticalPath > 0 {
+		stats.EstimatedSpeedup = float64(totalWork) / float64(criticalPath)
+	}
+	return stats
+}
+
+// trackUnknownCallee records m's callee as seen and, the first time it's
+// seen, counts it as unknown when it has no learned AvgGas yet.
+func (s *Scheduler) trackUnknownCallee(m *Message, seen map[string]struct{}, unknown *int) {
+	key := s.messageKey(m)
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+	if c, ok := s.calleeDict.Get(key); !ok || c.AvgGas == 0 {
+		*unknown++
+	}
+}
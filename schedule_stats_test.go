@@ -0,0 +1,73 @@
+package scheduler
+
+import "testing"
+
+func TestStatsReportsGenerationShapeAndSequentialTail(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}},
+		{ID: 3, To: "0xC", Sig: "h()", WriteSet: []string{"a"}},
+	})
+
+	stats := s.Stats(sched)
+	if stats.Generations != len(sched.Generations) {
+		t.Fatalf("expected Generations %d, got %d", len(sched.Generations), stats.Generations)
+	}
+	if len(stats.Widths) != len(sched.Generations) {
+		t.Fatalf("expected one width per generation, got %d", len(stats.Widths))
+	}
+	for i, gen := range sched.Generations {
+		if stats.Widths[i] != len(gen) {
+			t.Fatalf("generation %d: expected width %d, got %d", i, len(gen), stats.Widths[i])
+		}
+	}
+	if stats.SequentialTailLength != len(sched.SequentialTail) {
+		t.Fatalf("expected SequentialTailLength %d, got %d", len(sched.SequentialTail), stats.SequentialTailLength)
+	}
+}
+
+func TestStatsUnknownCalleeRatioDropsAsExecutionsAreRecorded(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+
+	before := s.Stats(s.New(msgs))
+	if before.UnknownCalleeRatio != 1 {
+		t.Fatalf("expected every callee unknown before any execution, got ratio %v", before.UnknownCalleeRatio)
+	}
+
+	s.RecordExecution("0xA", "f()", 100)
+	s.RecordExecution("0xB", "g()", 200)
+
+	after := s.Stats(s.New(msgs))
+	if after.UnknownCalleeRatio != 0 {
+		t.Fatalf("expected no unknown callees once both have recorded gas, got ratio %v", after.UnknownCalleeRatio)
+	}
+}
+
+func TestStatsEstimatedSpeedupReflectsGenerationParallelism(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}, GasLimit: 100},
+		{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}, GasLimit: 100},
+	})
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both independent messages in a single generation, got %+v", sched.Generations)
+	}
+
+	stats := s.Stats(sched)
+	if stats.EstimatedSpeedup != 2 {
+		t.Fatalf("expected a 2x speedup for two equally-costly parallel messages, got %v", stats.EstimatedSpeedup)
+	}
+}
+
+func TestStatsOnEmptyScheduleReportsZeroSpeedup(t *testing.T) {
+	s := NewScheduler()
+	stats := s.Stats(&Schedule{})
+	if stats.Generations != 0 || stats.EstimatedSpeedup != 0 || stats.UnknownCalleeRatio != 0 {
+		t.Fatalf("expected all-zero stats for an empty schedule, got %+v", stats)
+	}
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import "fmt"
+
+// Validate checks that sched is internally consistent with what s has
+// learned: no message ID appears more than once across all generations
+// and Deferred, and no two messages sharing a generation belong to
+// callees s would refuse to pack together (see Scheduler.messagesConflict).
+// It catches a hand-built or corrupted Schedule before an executor trusts
+// it to run generations in parallel.
+func (sched *Schedule) Validate(s *Scheduler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[uint64]struct{})
+	see := func(id uint64) error {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("scheduler: message %d appears more than once in the schedule", id)
+		}
+		seen[id] = struct{}{}
+		return nil
+	}
+
+	for gi, gen := range sched.Generations {
+		for i, m := range gen {
+			if err := see(m.ID); err != nil {
+				return err
+			}
+			for j := i + 1; j < len(gen); j++ {
+				if s.messagesConflict(m, gen[j]) {
+					return fmt.Errorf("scheduler: generation %d packs conflicting messages %d and %d together", gi, m.ID, gen[j].ID)
+				}
+			}
+		}
+	}
+
+	for _, m := range sched.Deferred {
+		if err := see(m.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import "testing"
+
+func TestValidateAcceptsAScheduleNewProduced(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+		{ID: 3, To: "0xC", Sig: "h()"},
+	})
+	if err := sched.Validate(s); err != nil {
+		t.Fatalf("expected a schedule New produced to validate, got %v", err)
+	}
+}
+
+func TestValidateRejectsConflictingMessagesInTheSameGeneration(t *testing.T) {
+	s := NewScheduler()
+	s.Add(calleeKey("0xa", "f()"), calleeKey("0xb", "g()"))
+
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{
+				{ID: 1, To: "0xA", Sig: "f()"},
+				{ID: 2, To: "0xB", Sig: "g()"},
+			},
+		},
+	}
+	if err := sched.Validate(s); err == nil {
+		t.Fatal("expected an error for conflicting messages packed into one generation")
+	}
+}
+
+func TestValidateRejectsDuplicateMessageIDs(t *testing.T) {
+	s := NewScheduler()
+
+	sched := &Schedule{
+		Generations: [][]*Message{
+			{{ID: 1, To: "0xA", Sig: "f()"}},
+		},
+		Deferred: []*Message{
+			{ID: 1, To: "0xB", Sig: "g()"},
+		},
+	}
+	if err := sched.Validate(s); err == nil {
+		t.Fatal("expected an error for a message ID repeated across generations and Deferred")
+	}
+}
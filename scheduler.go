@@ -0,0 +1,1183 @@
+// Package scheduler builds concurrent execution schedules for batches of
+// transactions, learning conflict patterns between callees over time so
+// that later batches touching the same contracts can be packed more
+// aggressively.
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arcology-network/scheduler/address"
+	"github.com/arcology-network/scheduler/arbitrator"
+	"github.com/arcology-network/scheduler/deferral"
+	"github.com/arcology-network/scheduler/metrics"
+	"github.com/arcology-network/scheduler/workerpool"
+)
+
+// prefixRule records that any access under Prefix conflicts with the
+// callee identified by CalleeKey. This bridges path-level arbitration
+// output (which only knows about state paths) with callee-level
+// scheduling input (which only knows about (address, signature) pairs).
+type prefixRule struct {
+	Prefix    string
+	CalleeKey string
+}
+
+// Scheduler builds execution schedules for a batch of messages based on
+// conflict patterns learned from prior blocks. Its exported methods are
+// safe to call concurrently: mu guards every field below except
+// calleeDict, whose own concurrency-safety depends on which CalleeStore
+// backs it (see NewScheduler vs NewConcurrentScheduler). Holding mu for
+// the duration of a call, including calleeDict access, is what keeps a
+// concurrent New from observing calleeDict and the conflict graph at
+// inconsistent points relative to each other.
+type Scheduler struct {
+	mu                  sync.Mutex
+	calleeDict          CalleeStore
+	conflicts           map[string]map[string]struct{}
+	conflictChain       map[string][]string
+	conflictCounts      map[string]uint64
+	conflictConfidence  uint64
+	prefixRules         []prefixRule
+	deferThreshold      uint64
+	deferDepth          int
+	maxGenSize          int
+	maxGenGas           uint64
+	maxGenerations      int
+	strategy            Strategy
+	evidence            map[string]arbitrator.Conflict
+	normalizeAddr       address.Normalizer
+	filterStats         *FilterStats
+	pool                *workerpool.Pool
+	maintenance         map[string]struct{}
+	observer            metrics.Observer
+	priorityCmp         PriorityComparator
+	sequential          map[string]struct{}
+	hotThreshold        uint64
+	hotCap              int
+	optimisticRate      float64
+	optimisticDecisions []OptimisticPair
+	rollbackHints       map[uint64][]uint64
+	balanceTouching     map[string]struct{}
+	crossCheckTransfers bool
+	hooks               Hooks
+	pathProfiles        map[string]map[string]struct{}
+	callGraph           map[string][]string
+	constraints         map[uint64][]uint64
+	bundles             [][]uint64
+}
+
+// OptimisticPair records that New packed two callees with a learned
+// conflict into the same generation anyway, because their empirical
+// conflict probability (see SetOptimisticConflictRate) fell below the
+// configured rate threshold. Callers that later observe the block's real
+// conflicts (e.g. via Arbitrator.Detect) should check whether either pair
+// actually collided, so the miss can feed back into Add/AddWithEvidence
+// for the next block instead of the optimism just repeating forever.
+type OptimisticPair struct {
+	A, B        string
+	Probability float64
+}
+
+// SetOptimisticConflictRate lets New optimistically parallelize a pair of
+// callees that have conflicted before but whose conflict probability —
+// observed conflicts divided by the busier callee's total call count —
+// falls below rate. A rate of 0 (the default) disables optimism entirely,
+// so any learned conflict still keeps its pair out of the same
+// generation. Each such decision is recorded and available afterward via
+// OptimisticDecisions for post-block learning.
+func (s *Scheduler) SetOptimisticConflictRate(rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.optimisticRate = rate
+}
+
+// OptimisticDecisions returns the pairs New most recently chose to
+// optimistically parallelize despite a learned conflict, per
+// SetOptimisticConflictRate. It is reset at the start of every New call.
+func (s *Scheduler) OptimisticDecisions() []OptimisticPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]OptimisticPair, len(s.optimisticDecisions))
+	copy(out, s.optimisticDecisions)
+	return out
+}
+
+// callsFor returns how many times the callee identified by key has been
+// observed, or 0 if it has never been seen.
+func (s *Scheduler) callsFor(key string) uint64 {
+	c, ok := s.calleeDict.Get(key)
+	if !ok {
+		return 0
+	}
+	return c.Calls
+}
+
+// conflictProbability estimates how often a and b actually conflict when
+// either is called: the pair's observation count divided by the busier
+// callee's total call count. Unseen callees are treated as certain to
+// conflict (probability 1) so a lack of history never triggers optimism.
+func (s *Scheduler) conflictProbability(a, b string) float64 {
+	calls := s.callsFor(a)
+	if bc := s.callsFor(b); bc > calls {
+		calls = bc
+	}
+	if calls == 0 {
+		return 1
+	}
+	return float64(s.conflictCounts[pairKey(a, b)]) / float64(calls)
+}
+
+// PriorityComparator reports whether message a should be placed ahead of
+// message b when New orders a batch before packing it into generations.
+// It must impose a strict total order — no two distinct messages compare
+// equal both ways — so that New produces the same schedule run to run
+// given the same input and learned state.
+type PriorityComparator func(a, b *Message) bool
+
+// defaultPriorityComparator orders by descending GasPrice, breaking ties
+// by ascending message ID so equal-GasPrice batches still schedule
+// deterministically.
+func defaultPriorityComparator(a, b *Message) bool {
+	if a.GasPrice != b.GasPrice {
+		return a.GasPrice > b.GasPrice
+	}
+	return a.ID < b.ID
+}
+
+// SetPriorityComparator overrides how New orders messages before packing
+// them into generations, and how it orders each generation and
+// Schedule.SequentialTail once packing and every ordering fixup (nonce,
+// AddConstraint) are done — e.g. by arrival order or by sender instead of
+// GasPrice — while keeping schedules reproducible. A pair the tail's own
+// nonce or AddConstraint ordering already settled is left alone
+// regardless of what cmp would otherwise say (see orderTail). Passing
+// nil restores defaultPriorityComparator, so a block builder never loses
+// fee revenue to an otherwise-arbitrary tie-break within a generation or
+// lane.
+func (s *Scheduler) SetPriorityComparator(cmp PriorityComparator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.priorityCmp = cmp
+}
+
+// orderByPriority returns msgs ordered by the Scheduler's configured
+// PriorityComparator (defaultPriorityComparator if none was set). New
+// uses it both to decide packing order up front and, again, to order
+// each final generation, since fixups applied in between
+// (enforceNonceOrder, enforceConstraintOrder, enforceMaxGenerations) can
+// otherwise leave a generation's message order following insertion order
+// rather than gas price. Schedule.Assign then balances lanes by
+// iterating a generation in this same order, so a lane's messages come
+// out gas-price ordered too. SequentialTail uses orderTail instead: a
+// generation's messages all run concurrently so a pure price sort can
+// never violate anything, but the tail runs its messages strictly one at
+// a time, where the same sort could invert an already-settled nonce or
+// AddConstraint order.
+func (s *Scheduler) orderByPriority(msgs []*Message) []*Message {
+	cmp := s.priorityCmp
+	if cmp == nil {
+		cmp = defaultPriorityComparator
+	}
+	ordered := make([]*Message, len(msgs))
+	copy(ordered, msgs)
+	sort.SliceStable(ordered, func(i, j int) bool { return cmp(ordered[i], ordered[j]) })
+	return ordered
+}
+
+// orderTail is orderByPriority for Schedule.SequentialTail specifically.
+// Unlike a generation, the tail executes strictly one message at a time
+// in its slice order, so a plain price sort could silently invert the
+// same-sender nonce order enforceNonceOrder already established, or an
+// AddConstraint edge enforceConstraintOrder already applied, once both
+// sides of either guarantee land in the tail together. constraints is
+// the Scheduler's constraint map as it stood right before
+// enforceConstraintOrder consumed it, used here only to recognize which
+// pairs must keep their relative order; it plays no other role, since
+// enforceConstraintOrder has already resolved every constraint it could.
+// Any pair the priority comparator would otherwise reorder is instead
+// left in its incoming (already correctly ordered) relative position.
+func (s *Scheduler) orderTail(msgs []*Message, constraints map[uint64][]uint64) []*Message {
+	cmp := s.priorityCmp
+	if cmp == nil {
+		cmp = defaultPriorityComparator
+	}
+	pos := make(map[*Message]int, len(msgs))
+	for i, m := range msgs {
+		pos[m] = i
+	}
+	before := make(map[uint64]map[uint64]bool, len(constraints))
+	for after, befores := range constraints {
+		set := make(map[uint64]bool, len(befores))
+		for _, id := range befores {
+			set[id] = true
+		}
+		before[after] = set
+	}
+	ordered := make([]*Message, len(msgs))
+	copy(ordered, msgs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.From != "" && a.From == b.From {
+			return pos[a] < pos[b]
+		}
+		if bs, ok := before[b.ID]; ok && bs[a.ID] {
+			return true
+		}
+		if as, ok := before[a.ID]; ok && as[b.ID] {
+			return false
+		}
+		return cmp(a, b)
+	})
+	return ordered
+}
+
+// SetObserver installs an Observer that New reports scheduling
+// statistics and latency to. A nil observer (the default) disables
+// reporting entirely.
+func (s *Scheduler) SetObserver(o metrics.Observer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = o
+}
+
+func (s *Scheduler) obs() metrics.Observer {
+	if s.observer == nil {
+		return metrics.Noop{}
+	}
+	return s.observer
+}
+
+// SetMaintenance marks the (to, sig) callee as maintenance-class (e.g. a
+// state expiry sweep or accounting job). New reserves the final
+// generation of every schedule for maintenance-class messages: they are
+// never interleaved into a parallel user generation, and — unlike other
+// callees — never deferred out of the block regardless of
+// SetDeferThreshold.
+func (s *Scheduler) SetMaintenance(to, sig string, maintenance bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := calleeKey(s.normalize(to), sig)
+	if maintenance {
+		if s.maintenance == nil {
+			s.maintenance = make(map[string]struct{})
+		}
+		s.maintenance[key] = struct{}{}
+		return
+	}
+	delete(s.maintenance, key)
+}
+
+func (s *Scheduler) isMaintenance(m *Message) bool {
+	_, ok := s.maintenance[s.messageKey(m)]
+	return ok
+}
+
+// SetWorkerPool installs a persistent worker pool for the Scheduler's
+// parallel sections (currently CrossCheck), reused block after block
+// instead of spawning fresh goroutines each time. A nil pool reverts to
+// sequential execution.
+func (s *Scheduler) SetWorkerPool(p *workerpool.Pool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pool = p
+}
+
+// SetAddressNormalizer overrides how the Scheduler canonicalizes callee
+// addresses before keying its learned statistics on them, for runtimes
+// whose native address format isn't lowercase EVM hex (address.EVM, the
+// default).
+func (s *Scheduler) SetAddressNormalizer(n address.Normalizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.normalizeAddr = n
+}
+
+func (s *Scheduler) normalize(addr string) string {
+	if s.normalizeAddr == nil {
+		return address.EVM(addr)
+	}
+	return s.normalizeAddr(addr)
+}
+
+// pairKey canonicalizes an unordered callee pair into a single map key.
+func pairKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+// NewScheduler returns an empty Scheduler with no learned conflicts,
+// backed by a plain map. Use NewConcurrentScheduler if the Scheduler will
+// be shared across goroutines.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		calleeDict:    make(mapCalleeStore),
+		conflicts:     make(map[string]map[string]struct{}),
+		conflictChain: make(map[string][]string),
+		filterStats:   newFilterStats(),
+	}
+}
+
+// NewConcurrentScheduler returns an empty Scheduler whose callee dict is
+// safe to read and update concurrently, for callers that plan multiple
+// blocks against one shared Scheduler from separate goroutines.
+func NewConcurrentScheduler() *Scheduler {
+	return &Scheduler{
+		calleeDict:    &syncCalleeStore{},
+		conflicts:     make(map[string]map[string]struct{}),
+		conflictChain: make(map[string][]string),
+		filterStats:   newFilterStats(),
+	}
+}
+
+// NewSchedulerWithStore returns an empty Scheduler backed by store instead
+// of the default in-memory map, for callers that need callee statistics
+// to persist across process restarts (see package leveldbstore for an
+// on-disk CalleeStore) or that supply their own custom backend.
+func NewSchedulerWithStore(store CalleeStore) *Scheduler {
+	return &Scheduler{
+		calleeDict:    store,
+		conflicts:     make(map[string]map[string]struct{}),
+		conflictChain: make(map[string][]string),
+		filterStats:   newFilterStats(),
+	}
+}
+
+// AddPrefixRule records that any read or write under pathPrefix conflicts
+// with calleeKey (e.g. "contractX/containerC/" conflicting with
+// "contractY:transfer()"), so New keeps messages under that prefix out of
+// the same generation as calls to that callee even though arbitration
+// never observed the two side by side.
+func (s *Scheduler) AddPrefixRule(pathPrefix, calleeKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefixRules = append(s.prefixRules, prefixRule{Prefix: pathPrefix, CalleeKey: calleeKey})
+}
+
+// touchesPrefixFor reports whether m accesses a path covered by a prefix
+// rule naming calleeKey.
+func (s *Scheduler) touchesPrefixFor(m *Message, calleeKey string) bool {
+	for _, rule := range s.prefixRules {
+		if rule.CalleeKey != calleeKey {
+			continue
+		}
+		for _, p := range m.WriteSet {
+			if strings.HasPrefix(p, rule.Prefix) {
+				return true
+			}
+		}
+		for _, p := range m.ReadSet {
+			if strings.HasPrefix(p, rule.Prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Add records an observed conflict between two callees, so future calls to
+// New keep messages that invoke them out of the same generation.
+func (s *Scheduler) Add(a, b string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addLocked(a, b)
+}
+
+// addLocked is Add's body, callable from other methods that already hold
+// s.mu so they don't deadlock re-acquiring it.
+func (s *Scheduler) addLocked(a, b string) {
+	if a == b {
+		s.filterStats.record("self-conflict", a)
+		return
+	}
+	if s.conflicts[a] == nil {
+		s.conflicts[a] = make(map[string]struct{})
+	}
+	if s.conflicts[b] == nil {
+		s.conflicts[b] = make(map[string]struct{})
+	}
+	_, alreadySeen := s.conflicts[a][b]
+	if !alreadySeen {
+		s.conflictChain[a] = append(s.conflictChain[a], b)
+	}
+	if _, seen := s.conflicts[b][a]; !seen {
+		s.conflictChain[b] = append(s.conflictChain[b], a)
+	}
+	s.conflicts[a][b] = struct{}{}
+	s.conflicts[b][a] = struct{}{}
+	if !alreadySeen {
+		s.fireConflictRecorded(a, b)
+	}
+	if s.conflictCounts == nil {
+		s.conflictCounts = make(map[string]uint64)
+	}
+	s.conflictCounts[pairKey(a, b)]++
+}
+
+// SetConflictConfidence sets the minimum number of times a pair of callees
+// must be observed conflicting, via Add or AddWithEvidence, before New
+// treats them as conflicting. The default, 0, means a single observation
+// is enough — matching the Scheduler's original behavior. Raising the
+// threshold keeps a spurious one-off conflict (e.g. from an airdrop that
+// happened to touch two otherwise-unrelated contracts) from permanently
+// serializing them.
+func (s *Scheduler) SetConflictConfidence(threshold uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conflictConfidence = threshold
+}
+
+// ConflictObservations returns how many times a and b have been recorded
+// as conflicting via Add or AddWithEvidence.
+func (s *Scheduler) ConflictObservations(a, b string) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conflictCounts[pairKey(a, b)]
+}
+
+// ConflictChain returns the callees known to conflict with callee, in the
+// order those conflicts were first recorded — useful when a caller needs
+// a deterministic ordering, e.g. to always resolve conflicts against the
+// earliest-known offender first.
+func (s *Scheduler) ConflictChain(callee string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chain := s.conflictChain[callee]
+	out := make([]string, len(chain))
+	copy(out, chain)
+	return out
+}
+
+// AddWithEvidence records a conflict between callees a and b like Add, and
+// additionally remembers evidence — the concrete arbitrator.Conflict that
+// triggered it — so a scheduling decision can later be explained rather
+// than just asserted.
+func (s *Scheduler) AddWithEvidence(a, b string, evidence arbitrator.Conflict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addWithEvidenceLocked(a, b, evidence)
+}
+
+func (s *Scheduler) addWithEvidenceLocked(a, b string, evidence arbitrator.Conflict) {
+	s.addLocked(a, b)
+	if s.evidence == nil {
+		s.evidence = make(map[string]arbitrator.Conflict)
+	}
+	s.evidence[pairKey(a, b)] = evidence
+}
+
+// Evidence returns the example conflict recorded for callees a and b via
+// AddWithEvidence, if any.
+func (s *Scheduler) Evidence(a, b string) (arbitrator.Conflict, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.evidence[pairKey(a, b)]
+	return e, ok
+}
+
+// RepairSymmetry scans the learned conflict graph for asymmetric edges (a
+// conflicts with b but not vice versa) or accidental self-edges — both of
+// which can slip in from data loaded through an external conflict DB —
+// and fixes them in place. It returns the number of edges repaired.
+func (s *Scheduler) RepairSymmetry() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repaired := 0
+	for a, peers := range s.conflicts {
+		for b := range peers {
+			if a == b {
+				delete(peers, b)
+				repaired++
+				continue
+			}
+			if _, ok := s.conflicts[b][a]; !ok {
+				s.addLocked(a, b)
+				repaired++
+			}
+		}
+	}
+	return repaired
+}
+
+func (s *Scheduler) conflicting(a, b string) bool {
+	peers, ok := s.conflicts[a]
+	if !ok {
+		return false
+	}
+	if _, ok = peers[b]; !ok {
+		return false
+	}
+	if s.conflictConfidence == 0 {
+		return true
+	}
+	return s.conflictCounts[pairKey(a, b)] >= s.conflictConfidence
+}
+
+// calleeFor gets-or-creates the Callee for (to, sig) without recording a
+// call against it.
+func (s *Scheduler) calleeFor(to, sig string) *Callee {
+	full := to
+	to = s.normalize(to)
+	key := calleeKey(to, sig)
+	c, ok := s.calleeDict.Get(key)
+	if !ok {
+		c = newCalleeWithFullAddress(to, full, sig)
+		s.calleeDict.Set(key, c)
+		s.fireCalleeAdded(to, sig)
+	}
+	return c
+}
+
+// messageKey is the normalized callee key for m.
+func (s *Scheduler) messageKey(m *Message) string {
+	return calleeKey(s.normalize(m.To), m.Sig)
+}
+
+// callee gets-or-creates the Callee for (to, sig) and records a call
+// against it.
+func (s *Scheduler) callee(to, sig string) *Callee {
+	c := s.calleeFor(to, sig)
+	c.Calls++
+	return c
+}
+
+// SetMaxGenerationSize caps how many messages New will pack into a single
+// generation. Once a generation reaches the cap, New spills further
+// non-conflicting messages into the next generation instead, giving an
+// execution queue that can't drain very wide generations without
+// stalling a way to apply backpressure. 0 means unlimited.
+func (s *Scheduler) SetMaxGenerationSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxGenSize = n
+}
+
+// Strategy selects the algorithm New uses to pack messages into
+// generations.
+type Strategy int
+
+const (
+	// StrategyGreedy assigns each message to the earliest generation it
+	// fits, considered in descending GasPrice order. Cheap, but can leave
+	// more generations than necessary on a dense conflict graph.
+	StrategyGreedy Strategy = iota
+	// StrategyGraphColoring builds an explicit conflict graph over the
+	// batch and colors it with a DSATUR heuristic, which tends to find
+	// fewer generations on dense graphs at the cost of more computation.
+	StrategyGraphColoring
+	// StrategyPathLevel packs greedily like StrategyGreedy, but ignores
+	// the coarse callee-pair conflict history and prefix rules in favor
+	// of per-message storage footprints — a declared access list when one
+	// is present, otherwise the learned path profile from Learn (see
+	// PathProfileOverlap). This lets two functions of the same contract
+	// that touch disjoint containers run concurrently even if the
+	// contract's other functions have a recorded pairwise conflict,
+	// at the cost of optimistically allowing anything neither message has
+	// declared or been observed touching.
+	StrategyPathLevel
+)
+
+// SetStrategy selects the packing algorithm New uses. The default is
+// StrategyGreedy.
+func (s *Scheduler) SetStrategy(strategy Strategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strategy = strategy
+}
+
+// SetMaxGenerationGas caps the combined estimated gas New will pack into
+// a single generation, using each message's estimated cost: its callee's
+// recorded AvgGas once one exists, otherwise its own GasLimit (see
+// Schedule.GenerationGas). Once a generation would exceed the cap, New spills
+// further messages into the next generation instead, so no generation
+// can blow past a block gas limit and estimated work stays balanced
+// across parallel lanes. 0 means unlimited.
+func (s *Scheduler) SetMaxGenerationGas(limit uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxGenGas = limit
+}
+
+// SetMaxGenerations caps how many generations New will produce for the
+// packed (non-maintenance, non-deferred) part of a batch. Once the cap
+// is reached, messages that would otherwise start yet another generation
+// are appended in order to Schedule.SequentialTail instead, so a
+// pathological batch with a long chain of mutually conflicting messages
+// costs one long serial tail rather than dozens of single-message
+// generations, each its own execution barrier. 0 (the default) leaves
+// the generation count unbounded.
+func (s *Scheduler) SetMaxGenerations(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxGenerations = n
+}
+
+// enforceMaxGenerations trims gens/gas down to SetMaxGenerations,
+// flattening any overflow generations' messages, in order, into a
+// sequential tail.
+func (s *Scheduler) enforceMaxGenerations(gens [][]*Message, gas []uint64) ([][]*Message, []uint64, []*Message) {
+	if s.maxGenerations <= 0 || len(gens) <= s.maxGenerations {
+		return gens, gas, nil
+	}
+	var tail []*Message
+	for _, gen := range gens[s.maxGenerations:] {
+		tail = append(tail, gen...)
+	}
+	return gens[:s.maxGenerations], gas[:s.maxGenerations], tail
+}
+
+// SetDeferThreshold configures how many prior calls a Deferrable callee
+// must have accumulated before New starts pushing its messages into
+// Schedule.Deferred instead of a generation. 0 disables deferral.
+func (s *Scheduler) SetDeferThreshold(threshold uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deferThreshold = threshold
+}
+
+// SetDeferDepth configures how many follow-up levels (see
+// Schedule.DeferredLevels) a deferred callee's repeated calls within one
+// batch are spread across, on top of SetDeferThreshold. A depth of 0 or 1
+// keeps every deferred message at a single level, matching the behavior
+// before this setting existed. A contract hot enough to have, say, 40
+// deferred calls in one batch with depth 4 gets 10 pushed to each of 4
+// follow-up blocks instead of all 40 piling into the very next one.
+func (s *Scheduler) SetDeferDepth(depth int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deferDepth = depth
+}
+
+// SetDeferrable marks the (to, sig) callee as eligible for deferral.
+func (s *Scheduler) SetDeferrable(to, sig string, deferrable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calleeFor(to, sig).Deferrable = deferrable
+}
+
+// SetDeferPolicy overrides the Scheduler-wide deferral defaults (see
+// SetDeferThreshold, SetDeferDepth) for the (to, sig) callee. Passing nil
+// clears the override, reverting the callee to those defaults. The
+// callee must still be marked Deferrable via SetDeferrable for the
+// policy to have any effect.
+func (s *Scheduler) SetDeferPolicy(to, sig string, policy *DeferPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calleeFor(to, sig).Defer = policy
+}
+
+// SetHotCalleeThreshold configures how many historical Calls a callee
+// must have accumulated before New treats it as "hot" for the purposes
+// of SetHotCalleeCap, on top of (and independent from) SetDeferrable and
+// SetDeferThreshold. 0 disables hot-callee detection.
+func (s *Scheduler) SetHotCalleeThreshold(threshold uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hotThreshold = threshold
+}
+
+// SetHotCalleeCap limits how many instances of a hot callee (see
+// SetHotCalleeThreshold) New will schedule in one batch; every instance
+// beyond the cap is deferred, even though nothing conflicted and the
+// callee was never explicitly marked Deferrable. This keeps a single
+// dominant contract from serializing the tail of a block on its own,
+// once it's called often enough across blocks to be identified as hot.
+func (s *Scheduler) SetHotCalleeCap(cap int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hotCap = cap
+}
+
+// planHotCalleeDeferrals returns the IDs of msgs beyond the configured
+// per-batch cap for any callee whose historical Calls has crossed
+// hotThreshold. Messages are considered in the order given, so combined
+// with New's priority ordering the highest-priority instances of a hot
+// callee are the ones kept in-block.
+func (s *Scheduler) planHotCalleeDeferrals(msgs []*Message) map[uint64]struct{} {
+	deferred := make(map[uint64]struct{})
+	if s.hotThreshold == 0 || s.hotCap <= 0 {
+		return deferred
+	}
+	seen := make(map[string]int)
+	for _, m := range msgs {
+		key := s.messageKey(m)
+		c, ok := s.calleeDict.Get(key)
+		if !ok || c.Calls < s.hotThreshold {
+			continue
+		}
+		seen[key]++
+		if seen[key] > s.hotCap {
+			deferred[m.ID] = struct{}{}
+		}
+	}
+	return deferred
+}
+
+// planDeferrals runs the shared deferral.Planner over msgs using this
+// scheduler's learned call counts and deferrable flags, returning each
+// deferred message ID's assigned level (see SetDeferDepth).
+func (s *Scheduler) planDeferrals(msgs []*Message) map[uint64]int {
+	candidates := make([]deferral.Candidate, 0, len(msgs))
+	for _, m := range msgs {
+		candidates = append(candidates, deferral.Candidate{ID: m.ID, CalleeKey: s.messageKey(m), GasPrice: m.GasPrice})
+	}
+
+	callCounts := make(map[string]uint64, s.calleeDict.Len())
+	deferrable := make(map[string]bool, s.calleeDict.Len())
+	perKeyMinInstances := make(map[string]uint64)
+	perKeyDepth := make(map[string]int)
+	perKeyTiebreak := make(map[string]deferral.Tiebreak)
+	s.calleeDict.ForEach(func(key string, c *Callee) {
+		callCounts[key] = c.Calls
+		deferrable[key] = c.Deferrable
+		if c.Defer != nil {
+			if c.Defer.MinInstances > 0 {
+				perKeyMinInstances[key] = c.Defer.MinInstances
+			}
+			if c.Defer.MaxRounds > 0 {
+				perKeyDepth[key] = c.Defer.MaxRounds
+			}
+			perKeyTiebreak[key] = c.Defer.Tiebreak
+		}
+	})
+
+	planner := &deferral.Planner{
+		CallCounts:         callCounts,
+		Deferrable:         deferrable,
+		Threshold:          s.deferThreshold,
+		Depth:              s.deferDepth,
+		PerKeyMinInstances: perKeyMinInstances,
+		PerKeyDepth:        perKeyDepth,
+		PerKeyTiebreak:     perKeyTiebreak,
+	}
+	return planner.PlanLevels(candidates)
+}
+
+// New plans a schedule for msgs: messages whose callee is due for
+// deferral (see SetDeferThreshold) go to Schedule.Deferred, everything
+// else is packed into generations by the Scheduler's configured Strategy
+// (see SetStrategy; StrategyGreedy is the default). Maintenance-class
+// messages (see SetMaintenance) are held back from that packing and
+// placed together in one reserved final generation instead — they never
+// share a generation with user messages and are never deferred.
+func (s *Scheduler) New(msgs []*Message) *Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sched, _ := s.newLocked(context.Background(), msgs)
+	return sched
+}
+
+// NewWithContext behaves like New, but checks ctx between messages while
+// packing and, once the context is canceled or its deadline expires,
+// stops iterating the parallel-set search and dumps every message it
+// hadn't gotten to yet into Schedule.SequentialTail instead of dropping
+// them — so a validator with a fixed block-building budget gets back a
+// complete, valid schedule bounded to that budget, trading parallel
+// schedule quality for bounded latency, rather than an incomplete one.
+// The returned Schedule is always non-nil and always accounts for every
+// message in msgs; ctx.Err() is returned alongside it purely to tell the
+// caller the budget was in fact exceeded.
+func (s *Scheduler) NewWithContext(ctx context.Context, msgs []*Message) (*Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newLocked(ctx, msgs)
+}
+
+// NewWithBudget behaves like NewWithContext, but takes a plain wall-clock
+// duration instead of requiring the caller to build their own context.
+func (s *Scheduler) NewWithBudget(msgs []*Message, budget time.Duration) (*Schedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+	return s.NewWithContext(ctx, msgs)
+}
+
+// newLocked is New's body, callable from other methods that already hold
+// s.mu so they don't deadlock re-acquiring it.
+func (s *Scheduler) newLocked(ctx context.Context, msgs []*Message) (*Schedule, error) {
+	start := time.Now()
+	sched := &Schedule{}
+	s.optimisticDecisions = nil
+	s.rollbackHints = nil
+
+	for _, m := range msgs {
+		s.callee(m.To, m.Sig)
+	}
+
+	bundleGroups, bundleIDs := s.extractBundlesLocked(msgs)
+
+	var maintenance []*Message
+	regular := make([]*Message, 0, len(msgs))
+	for _, m := range msgs {
+		if _, ok := bundleIDs[m.ID]; ok {
+			continue
+		}
+		if s.isMaintenance(m) {
+			maintenance = append(maintenance, m)
+		} else {
+			regular = append(regular, m)
+		}
+	}
+
+	ordered := s.orderByPriority(regular)
+	levels := s.planDeferrals(regular)
+	for id := range s.planHotCalleeDeferrals(ordered) {
+		if _, ok := levels[id]; !ok {
+			levels[id] = 0
+		}
+	}
+	var deferredMsgs []*Message
+	var toSchedule []*Message
+	maxLevel := 0
+	for _, m := range ordered {
+		if lvl, ok := levels[m.ID]; ok {
+			deferredMsgs = append(deferredMsgs, m)
+			if lvl > maxLevel {
+				maxLevel = lvl
+			}
+		} else {
+			toSchedule = append(toSchedule, m)
+		}
+	}
+	sched.Deferred = deferredMsgs
+	if len(deferredMsgs) > 0 {
+		sched.DeferredLevels = make([][]*Message, maxLevel+1)
+		for _, m := range deferredMsgs {
+			lvl := levels[m.ID]
+			sched.DeferredLevels[lvl] = append(sched.DeferredLevels[lvl], m)
+			s.fireDeferred(m)
+		}
+	}
+
+	var err error
+	var budgetRemainder []*Message
+	switch s.strategy {
+	case StrategyGraphColoring:
+		sched.Generations, sched.GenerationGas, budgetRemainder, err = s.packByGraphColoring(ctx, toSchedule)
+	case StrategyPathLevel:
+		sched.Generations, sched.GenerationGas, budgetRemainder, err = s.packByFootprint(ctx, toSchedule)
+	default:
+		sched.Generations, sched.GenerationGas, budgetRemainder, err = s.packGreedily(ctx, toSchedule)
+	}
+	sched.Generations, sched.GenerationGas = enforceNonceOrder(sched.Generations, sched.GenerationGas, s.estimatedGas)
+	constraintsForTail := s.constraints
+	sched.Generations, sched.GenerationGas = s.enforceConstraintOrder(sched.Generations, sched.GenerationGas)
+	sched.Generations, sched.GenerationGas, sched.SequentialTail = s.enforceMaxGenerations(sched.Generations, sched.GenerationGas)
+	sched.SequentialTail = append(sched.SequentialTail, budgetRemainder...)
+	for i := range sched.Generations {
+		sched.Generations[i] = s.orderByPriority(sched.Generations[i])
+	}
+	if len(sched.SequentialTail) > 0 {
+		sched.SequentialTail = s.orderTail(sched.SequentialTail, constraintsForTail)
+	}
+	for _, group := range bundleGroups {
+		sched.SequentialTail = append(sched.SequentialTail, group...)
+	}
+
+	if len(maintenance) > 0 {
+		sched.Generations = append(sched.Generations, maintenance)
+		sched.GenerationGas = append(sched.GenerationGas, s.estimatedGenerationGas(maintenance))
+	}
+	sched.RollbackHints = s.rollbackHints
+	sched.CallCounts = s.computeCallCounts(sched.Generations)
+
+	obs := s.obs()
+	obs.ObserveCalleeCount(s.calleeDict.Len())
+	obs.ObserveGenerations(len(sched.Generations))
+	obs.ObserveDeferred(len(sched.Deferred))
+	width := 0
+	for _, gen := range sched.Generations {
+		if len(gen) > width {
+			width = len(gen)
+		}
+	}
+	obs.ObserveParallelWidth(width)
+	obs.ObserveNewLatency(time.Since(start))
+	s.fireScheduleBuilt(sched)
+	return sched, err
+}
+
+// packGreedily is the default packing strategy: each message, considered
+// in descending GasPrice order, joins the earliest generation it fits
+// without conflict, or starts a new one. ctx is checked before each
+// message so a canceled context (see NewWithContext, NewWithBudget) stops
+// packing early instead of running to completion; the caller gets back
+// whatever was packed so far, plus the unprocessed remainder so newLocked
+// can fall back to running it through the sequential set rather than
+// dropping it.
+func (s *Scheduler) packGreedily(ctx context.Context, msgs []*Message) ([][]*Message, []uint64, []*Message, error) {
+	return s.packGreedilyWith(ctx, msgs, s.messagesConflict)
+}
+
+// packGreedilyWith is packGreedily parametrized over the conflict
+// predicate, so alternative strategies (see StrategyPathLevel) can reuse
+// the same generation-filling loop with a different notion of what
+// conflicts.
+func (s *Scheduler) packGreedilyWith(ctx context.Context, msgs []*Message, conflicts func(a, b *Message) bool) ([][]*Message, []uint64, []*Message, error) {
+	var gens [][]*Message
+	var gas []uint64
+	for idx, m := range msgs {
+		if err := ctx.Err(); err != nil {
+			return gens, gas, msgs[idx:], err
+		}
+		placed := false
+		for i, gen := range gens {
+			if s.fitsGenerationWith(m, gen, conflicts) {
+				gens[i] = append(gen, m)
+				gas[i] += s.estimatedGas(m)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			gens = append(gens, []*Message{m})
+			gas = append(gas, s.estimatedGas(m))
+		}
+	}
+	return gens, gas, nil, nil
+}
+
+// fitsGeneration reports whether m can join gen without conflicting, by
+// callee history or by prefix rule, with anything already placed there.
+func (s *Scheduler) fitsGeneration(m *Message, gen []*Message) bool {
+	return s.fitsGenerationWith(m, gen, s.messagesConflict)
+}
+
+// fitsGenerationWith is fitsGeneration parametrized over the conflict
+// predicate.
+func (s *Scheduler) fitsGenerationWith(m *Message, gen []*Message, conflicts func(a, b *Message) bool) bool {
+	if s.maxGenSize > 0 && len(gen) >= s.maxGenSize {
+		return false
+	}
+	if s.maxGenGas > 0 && s.estimatedGenerationGas(gen)+s.estimatedGas(m) > s.maxGenGas {
+		return false
+	}
+	for _, other := range gen {
+		if conflicts(m, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// messagesConflict reports whether a and b cannot share a generation.
+// Same-sender messages must always stay ordered regardless of anything
+// else, and a message invoking a callee demoted via MarkSequential
+// always conflicts with everything. If both declare an access list
+// (ReadSet/WriteSet, e.g. from an
+// EIP-2930-style access list), that precise, per-transaction information
+// overrules the coarse learned callee dictionary entirely — two
+// transactions on conflicting callees can still run concurrently if their
+// declared paths don't actually overlap, and conversely two transactions
+// on unrelated callees still conflict if their paths do. Only when a
+// message has no declared access list does messagesConflict fall back to
+// the callee-pair history, prefix rules, and (if enabled) the
+// SetCrossCheckTransferBalances pass.
+func (s *Scheduler) messagesConflict(a, b *Message) bool {
+	if a.From != "" && a.From == b.From {
+		return true
+	}
+	if s.isSequential(s.messageKey(a)) || s.isSequential(s.messageKey(b)) {
+		return true
+	}
+	if hasAccessList(a) && hasAccessList(b) {
+		return accessListsConflict(a, b)
+	}
+	keyA := s.messageKey(a)
+	keyB := s.messageKey(b)
+	if keyA == keyB {
+		return true
+	}
+	if s.conflicting(keyA, keyB) {
+		p, ok := s.optimisticallyCleared(keyA, keyB)
+		if !ok {
+			return true
+		}
+		s.optimisticDecisions = append(s.optimisticDecisions, OptimisticPair{A: keyA, B: keyB, Probability: p})
+		if s.rollbackHints == nil {
+			s.rollbackHints = make(map[uint64][]uint64)
+		}
+		s.rollbackHints[a.ID] = append(s.rollbackHints[a.ID], b.ID)
+		s.rollbackHints[b.ID] = append(s.rollbackHints[b.ID], a.ID)
+	}
+	if s.touchesPrefixFor(a, keyB) || s.touchesPrefixFor(b, keyA) {
+		return true
+	}
+	if s.pathProfileOverlapLocked(keyA, keyB) {
+		return true
+	}
+	if s.callGraphConflictLocked(keyA, keyB) {
+		return true
+	}
+	return s.crossCheckTransfers && s.transferConflictsWithBalanceTouch(a, b)
+}
+
+// SetTouchesBalance marks whether the (to, sig) callee is known to read or
+// write its own contract's native balance (e.g. a withdraw or fee-sweep
+// function), for use by SetCrossCheckTransferBalances.
+func (s *Scheduler) SetTouchesBalance(to, sig string, touches bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := calleeKey(s.normalize(to), sig)
+	if touches {
+		if s.balanceTouching == nil {
+			s.balanceTouching = make(map[string]struct{})
+		}
+		s.balanceTouching[key] = struct{}{}
+		return
+	}
+	delete(s.balanceTouching, key)
+}
+
+// SetCrossCheckTransferBalances enables an optional pass over
+// messagesConflict that catches a conflict New's coarse learned history
+// otherwise misses: a plain native transfer (a Message with an empty Sig)
+// into an address whose balance is also read or written by a contract
+// call to that same address, marked via SetTouchesBalance. A transfer's
+// callee key carries no signature, so it never matches the learned
+// conflict history recorded against a same-address contract call — this
+// pass keys transfers by recipient and cross-checks them against known
+// balance-touching callees instead. Disabled by default, since it costs
+// an extra lookup per pair and most callers have no balance-touching
+// callees to mark.
+func (s *Scheduler) SetCrossCheckTransferBalances(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.crossCheckTransfers = enabled
+}
+
+// transferConflictsWithBalanceTouch reports whether one of a or b is a
+// plain transfer into an address the other is known (via
+// SetTouchesBalance) to touch the balance of.
+func (s *Scheduler) transferConflictsWithBalanceTouch(a, b *Message) bool {
+	return s.transferHitsBalanceTouch(a, b) || s.transferHitsBalanceTouch(b, a)
+}
+
+func (s *Scheduler) transferHitsBalanceTouch(transfer, other *Message) bool {
+	if transfer.Sig != "" {
+		return false
+	}
+	if s.normalize(transfer.To) != s.normalize(other.To) {
+		return false
+	}
+	_, ok := s.balanceTouching[s.messageKey(other)]
+	return ok
+}
+
+// optimisticallyCleared reports whether a learned conflict between a and b
+// should be ignored this round because SetOptimisticConflictRate is
+// enabled and their empirical conflict probability falls below it.
+func (s *Scheduler) optimisticallyCleared(a, b string) (float64, bool) {
+	if s.optimisticRate <= 0 {
+		return 0, false
+	}
+	p := s.conflictProbability(a, b)
+	if p >= s.optimisticRate {
+		return 0, false
+	}
+	return p, true
+}
+
+// hasAccessList reports whether m declares any read or write paths up
+// front, making it eligible for the precise access-list conflict check
+// in messagesConflict instead of the coarse callee-history fallback.
+func hasAccessList(m *Message) bool {
+	return len(m.ReadSet) > 0 || len(m.WriteSet) > 0
+}
+
+// accessListsConflict reports whether a and b's declared access lists
+// overlap: either wrote the same path, or one wrote a path the other
+// read.
+func accessListsConflict(a, b *Message) bool {
+	return pathsOverlap(a.WriteSet, b.WriteSet) ||
+		pathsOverlap(a.WriteSet, b.ReadSet) ||
+		pathsOverlap(a.ReadSet, b.WriteSet)
+}
+
+func pathsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, p := range a {
+		set[p] = struct{}{}
+	}
+	for _, p := range b {
+		if _, ok := set[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// generationGas sums the GasLimit of every message already placed in gen.
+func generationGas(gen []*Message) uint64 {
+	var gas uint64
+	for _, m := range gen {
+		gas += m.GasLimit
+	}
+	return gas
+}
+
+// WarmUpReport summarizes what a Scheduler has learned so far, so callers
+// can tell whether it has seen enough traffic to make good scheduling
+// decisions before relying on it for a live block.
+type WarmUpReport struct {
+	Callees           int
+	DeferrableCallees int
+	ConflictPairs     int
+	PrefixRules       int
+	TotalCalls        uint64
+}
+
+// WarmUpReport builds a report of the Scheduler's current learned state.
+func (s *Scheduler) WarmUpReport() WarmUpReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := WarmUpReport{
+		Callees:     s.calleeDict.Len(),
+		PrefixRules: len(s.prefixRules),
+	}
+	s.calleeDict.ForEach(func(_ string, c *Callee) {
+		report.TotalCalls += c.Calls
+		if c.Deferrable {
+			report.DeferrableCallees++
+		}
+	})
+	seen := make(map[string]struct{})
+	for a, peers := range s.conflicts {
+		for b := range peers {
+			key := a + "|" + b
+			rev := b + "|" + a
+			if _, ok := seen[rev]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			report.ConflictPairs++
+		}
+	}
+	return report
+}
+
+// Optimize re-packs an already-built schedule, useful after a follow-up
+// pass (e.g. deferral) has changed which messages remain to be run. It
+// then runs a compaction pass that merges consecutive generations whose
+// combined callee sets are conflict-free, so fragmentation left behind by
+// the earlier pass doesn't inflate the generation (barrier) count.
+func (s *Scheduler) Optimize(sched *Schedule) *Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flat := make([]*Message, 0)
+	for _, gen := range sched.Generations {
+		flat = append(flat, gen...)
+	}
+	optimized, _ := s.newLocked(context.Background(), flat)
+	optimized.Deferred = sched.Deferred
+	optimized.DeferredLevels = sched.DeferredLevels
+	return s.compactGenerations(optimized)
+}
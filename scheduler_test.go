@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+func TestNewSeparatesPrefixConflictingMessages(t *testing.T) {
+	s := NewScheduler()
+	s.AddPrefixRule("0xA/containerC/", calleeKey("0xb", "transfer()"))
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "clear()", WriteSet: []string{"0xA/containerC/1"}},
+		{ID: 2, To: "0xB", Sig: "transfer()"},
+	}
+
+	sched := s.New(msgs)
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected messages to land in separate generations, got %v", sched.Generations)
+	}
+}
+
+func TestNewAppliesBackpressureViaMaxGenerationSize(t *testing.T) {
+	s := NewScheduler()
+	s.SetMaxGenerationSize(1)
+
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+	sched := s.New(msgs)
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected backpressure to split into two generations, got %v", sched.Generations)
+	}
+}
+
+func TestWarmUpReportSummarizesLearnedState(t *testing.T) {
+	s := NewScheduler()
+	s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()"}, {ID: 2, To: "0xB", Sig: "g()"}})
+	s.Add(calleeKey("0xA", "f()"), calleeKey("0xB", "g()"))
+
+	report := s.WarmUpReport()
+	if report.Callees != 2 || report.ConflictPairs != 1 || report.TotalCalls != 2 {
+		t.Fatalf("unexpected warm-up report: %+v", report)
+	}
+}
+
+func TestSetAddressNormalizerUnifiesEquivalentAddresses(t *testing.T) {
+	s := NewScheduler()
+	s.SetAddressNormalizer(func(addr string) string { return addr }) // treat addresses as already canonical
+	s.Add(calleeKey("Contract#1", "f()"), calleeKey("Contract#2", "g()"))
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "Contract#1", Sig: "f()"},
+		{ID: 2, To: "Contract#2", Sig: "g()"},
+	})
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected non-EVM addresses to still be recognized as conflicting, got %v", sched.Generations)
+	}
+}
+
+func TestNewComputesPerGenerationGas(t *testing.T) {
+	s := NewScheduler()
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xA", Sig: "f()", GasLimit: 100},
+		{ID: 2, To: "0xB", Sig: "g()", GasLimit: 50},
+	})
+	if len(sched.GenerationGas) != 1 || sched.GenerationGas[0] != 150 {
+		t.Fatalf("expected generation gas total of 150, got %v", sched.GenerationGas)
+	}
+}
+
+func TestRepairSymmetryFixesAsymmetricAndSelfEdges(t *testing.T) {
+	s := NewScheduler()
+	s.conflicts["a"] = map[string]struct{}{"b": {}, "a": {}} // asymmetric + self-edge, as if loaded externally
+
+	repaired := s.RepairSymmetry()
+	if repaired != 2 {
+		t.Fatalf("expected two repairs, got %d", repaired)
+	}
+	if !s.conflicting("b", "a") {
+		t.Fatal("expected the missing reverse edge to be added")
+	}
+	if s.conflicting("a", "a") {
+		t.Fatal("expected the self-edge to be removed")
+	}
+}
+
+func TestConflictChainPreservesDiscoveryOrder(t *testing.T) {
+	s := NewScheduler()
+	s.Add("a", "b")
+	s.Add("a", "c")
+	s.Add("a", "b") // duplicate, must not appear twice
+
+	chain := s.ConflictChain("a")
+	if len(chain) != 2 || chain[0] != "b" || chain[1] != "c" {
+		t.Fatalf("expected ordered chain [b c], got %v", chain)
+	}
+}
+
+func TestNewConcurrentSchedulerLearnsCallees(t *testing.T) {
+	s := NewConcurrentScheduler()
+	s.New([]*Message{{ID: 1, To: "0xA", Sig: "f()"}})
+	if s.WarmUpReport().Callees != 1 {
+		t.Fatalf("expected concurrent scheduler to record the callee it saw")
+	}
+}
+
+func TestAddWithEvidenceIsRetrievableEitherOrder(t *testing.T) {
+	s := NewScheduler()
+	evidence := arbitrator.Conflict{A: 1, B: 2, Path: "0xA/balance", Reason: arbitrator.ReasonWriteWrite}
+	s.AddWithEvidence("a", "b", evidence)
+
+	got, ok := s.Evidence("b", "a")
+	if !ok || got != evidence {
+		t.Fatalf("expected evidence to be retrievable regardless of pair order, got %+v, %v", got, ok)
+	}
+}
+
+func TestNewPacksUnrelatedCalleesTogether(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+	sched := s.New(msgs)
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected both messages in one generation, got %v", sched.Generations)
+	}
+}
@@ -0,0 +1,197 @@
+// Package simulator generates synthetic transaction workloads and drives
+// them through a Scheduler and Arbitrator end to end, so scheduling and
+// deferral parameters (e.g. Scheduler.SetDeferThreshold,
+// Scheduler.SetDeferDepth) can be tuned against measurable outcomes —
+// generation counts, re-execution rates, estimated speedup — instead of
+// guesswork.
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/arcology-network/scheduler"
+	"github.com/arcology-network/scheduler/arbitrator"
+)
+
+// Workload describes a synthetic batch of messages to generate.
+type Workload struct {
+	// Messages is how many messages the batch contains.
+	Messages int
+	// Contracts is how many distinct callee addresses messages are drawn
+	// from. Values <= 0 are treated as 1.
+	Contracts int
+	// Skew biases message generation toward the first few contracts: 0
+	// (the default) spreads messages uniformly across Contracts; higher
+	// values concentrate more of the batch on a handful of "hot"
+	// contracts, following a Zipf-like 1/(rank+1)^Skew distribution.
+	Skew float64
+	// ConflictDensity is the probability, in [0,1], that any two distinct
+	// contracts are wired together by a shared state path — modeling,
+	// e.g., a token or library contract several other contracts read or
+	// write in the course of handling a call. 0 means every contract's
+	// state is fully independent.
+	ConflictDensity float64
+	// Seed makes generation reproducible: the same Workload field values
+	// and Seed always produce an identical batch of messages.
+	Seed int64
+}
+
+// Report summarizes one simulated block.
+type Report struct {
+	Messages int
+	// Generations is how many sequential execution barriers the
+	// Scheduler packed the workload into.
+	Generations int
+	// ParallelWidth is the size of the largest generation.
+	ParallelWidth int
+	// ReExecuted is how many messages the Arbitrator flagged as party to
+	// a real conflict within their assigned generation — i.e. how many
+	// would need to be rolled back and re-run because the Scheduler
+	// optimistically packed them together without knowing their true
+	// access lists.
+	ReExecuted int
+	// ReExecutionRate is ReExecuted / Messages, 0 when Messages is 0.
+	ReExecutionRate float64
+	// EstimatedSpeedup is Messages / Generations, a rough measure of how
+	// much parallelism the schedule extracted: 1 means fully serial,
+	// Messages means every message ran in a single generation together.
+	EstimatedSpeedup float64
+}
+
+// Run generates a batch from w and schedules it with sched. The messages
+// handed to sched carry no declared access lists, so New falls back
+// entirely to whatever conflict history and deferral settings sched was
+// configured with — exactly the position a real Scheduler is in before a
+// block has ever been arbitrated. Run then arbitrates each generation
+// independently against the workload's true (but, to sched, unseen)
+// access lists, the same way Scheduler.CrossCheck reconciles an
+// optimistic schedule against real execution: conflicts across
+// generations are expected and not counted, only conflicts within one
+// generation the schedule believed was safe to run concurrently. sched
+// should already be configured (deferral thresholds, conflict hints,
+// etc.) the way the caller wants to evaluate; Run does not reset or
+// configure it, so a caller can replay the same Workload against several
+// configurations of a fresh Scheduler to compare them.
+func Run(sched *scheduler.Scheduler, w Workload) Report {
+	g := generate(w)
+	sc := sched.New(g.msgs)
+
+	reExecuted := 0
+	width := 0
+	for _, gen := range sc.Generations {
+		if len(gen) > width {
+			width = len(gen)
+		}
+		accs := make([]arbitrator.Access, len(gen))
+		for i, m := range gen {
+			accs[i] = arbitrator.Access{ID: m.ID, WriteSet: g.writeSets[m.ID]}
+		}
+		conflicts, _ := arbitrator.New().Detect(accs)
+		seen := make(map[uint64]struct{}, len(conflicts)*2)
+		for _, c := range conflicts {
+			seen[c.A] = struct{}{}
+			seen[c.B] = struct{}{}
+		}
+		reExecuted += len(seen)
+	}
+
+	report := Report{
+		Messages:      len(g.msgs),
+		Generations:   len(sc.Generations),
+		ParallelWidth: width,
+		ReExecuted:    reExecuted,
+	}
+	if report.Messages > 0 {
+		report.ReExecutionRate = float64(reExecuted) / float64(report.Messages)
+	}
+	if report.Generations > 0 {
+		report.EstimatedSpeedup = float64(report.Messages) / float64(report.Generations)
+	}
+	return report
+}
+
+// sharedPath records that contracts i and j both touch path, modeling a
+// dependency between two otherwise-unrelated contracts.
+type sharedPath struct {
+	i, j int
+	path string
+}
+
+// batch is a generated Workload: the Messages to hand to a Scheduler,
+// deliberately stripped of access lists, plus the true write set each
+// message ID would exercise, kept aside for arbitration.
+type batch struct {
+	msgs      []*scheduler.Message
+	writeSets map[uint64][]string
+}
+
+// generate deterministically builds a batch from w. Every message invokes
+// call() on one of w.Contracts synthetic addresses ("contract-<n>"), and
+// its true write set is that contract's own state path plus any
+// sharedPath wired to it.
+func generate(w Workload) batch {
+	contracts := w.Contracts
+	if contracts <= 0 {
+		contracts = 1
+	}
+	rng := rand.New(rand.NewSource(w.Seed))
+
+	weights := make([]float64, contracts)
+	total := 0.0
+	for i := range weights {
+		weight := 1.0
+		if w.Skew > 0 {
+			weight = 1.0 / math.Pow(float64(i+1), w.Skew)
+		}
+		weights[i] = weight
+		total += weight
+	}
+
+	var shared []sharedPath
+	for i := 0; i < contracts; i++ {
+		for j := i + 1; j < contracts; j++ {
+			if rng.Float64() < w.ConflictDensity {
+				shared = append(shared, sharedPath{i: i, j: j, path: fmt.Sprintf("shared/%d-%d", i, j)})
+			}
+		}
+	}
+
+	b := batch{
+		msgs:      make([]*scheduler.Message, w.Messages),
+		writeSets: make(map[uint64][]string, w.Messages),
+	}
+	for i := 0; i < w.Messages; i++ {
+		id := uint64(i + 1)
+		idx := sampleWeighted(rng, weights, total)
+		writeSet := []string{fmt.Sprintf("contract/%d/state", idx)}
+		for _, sp := range shared {
+			if sp.i == idx || sp.j == idx {
+				writeSet = append(writeSet, sp.path)
+			}
+		}
+		b.msgs[i] = &scheduler.Message{
+			ID:       id,
+			To:       fmt.Sprintf("contract-%d", idx),
+			Sig:      "call()",
+			GasPrice: uint64(rng.Intn(100) + 1),
+		}
+		b.writeSets[id] = writeSet
+	}
+	return b
+}
+
+// sampleWeighted draws a contract index proportional to weights, whose
+// entries sum to total.
+func sampleWeighted(rng *rand.Rand, weights []float64, total float64) int {
+	target := rng.Float64() * total
+	sum := 0.0
+	for i, weight := range weights {
+		sum += weight
+		if target < sum {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
@@ -0,0 +1,51 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler"
+)
+
+func TestRunIsDeterministicForTheSameSeed(t *testing.T) {
+	w := Workload{Messages: 200, Contracts: 10, Skew: 1.2, ConflictDensity: 0.3, Seed: 42}
+
+	r1 := Run(scheduler.NewScheduler(), w)
+	r2 := Run(scheduler.NewScheduler(), w)
+
+	if r1 != r2 {
+		t.Fatalf("expected identical reports for the same seed, got %+v vs %+v", r1, r2)
+	}
+}
+
+func TestRunReportsAllMessagesAccountedFor(t *testing.T) {
+	w := Workload{Messages: 50, Contracts: 5, Seed: 1}
+	r := Run(scheduler.NewScheduler(), w)
+
+	if r.Messages != 50 {
+		t.Fatalf("expected 50 messages generated, got %d", r.Messages)
+	}
+	if r.Generations == 0 {
+		t.Fatalf("expected at least one generation")
+	}
+	if r.ReExecuted > r.Messages {
+		t.Fatalf("re-executed count %d cannot exceed message count %d", r.ReExecuted, r.Messages)
+	}
+}
+
+func TestZeroConflictDensityNeverReExecutes(t *testing.T) {
+	w := Workload{Messages: 100, Contracts: 20, ConflictDensity: 0, Seed: 7}
+	r := Run(scheduler.NewScheduler(), w)
+
+	if r.ReExecuted != 0 {
+		t.Fatalf("expected no conflicts with independent contract state, got %d re-executed", r.ReExecuted)
+	}
+}
+
+func TestHighConflictDensityDrivesConflictsAmongSharedContracts(t *testing.T) {
+	w := Workload{Messages: 200, Contracts: 10, ConflictDensity: 1, Seed: 3}
+	r := Run(scheduler.NewScheduler(), w)
+
+	if r.ReExecuted == 0 {
+		t.Fatalf("expected fully wired contracts to produce some conflicts")
+	}
+}
@@ -0,0 +1,144 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/arbitrator"
+
+// Snapshot is an immutable, in-memory copy of a Scheduler's learned
+// state, taken by Scheduler.Snapshot. It exists so a block proposer can
+// let a Scheduler learn speculatively while building a candidate block —
+// recording conflicts, deferring hot callees, and so on — then discard
+// that speculative learning with RestoreFromSnapshot if the block isn't
+// finalized, without paying ExportJSON/ImportJSON's serialization cost.
+// Learned path profiles (see Learn) and call-graph edges are not part of
+// a Snapshot, so whatever was learned into them survives a restore.
+// Restoring replaces the Scheduler's calleeDict with
+// a plain in-memory copy of the snapshot, so Snapshot/RestoreFromSnapshot
+// are only meant for Schedulers backed by the default store (NewScheduler
+// or NewConcurrentScheduler) — a Scheduler built with
+// NewSchedulerWithStore loses its custom backend on restore.
+type Snapshot struct {
+	calleeDict     mapCalleeStore
+	conflicts      map[string]map[string]struct{}
+	conflictChain  map[string][]string
+	conflictCounts map[string]uint64
+	prefixRules    []prefixRule
+	evidence       map[string]arbitrator.Conflict
+	maintenance    map[string]struct{}
+	sequential     map[string]struct{}
+}
+
+// Snapshot returns an immutable copy of the Scheduler's current learned
+// state: every Callee record, the conflict graph and its observation
+// counts, prefix rules, evidence, and the maintenance/sequential flags.
+// Mutating the Scheduler afterward never affects the returned Snapshot.
+func (s *Scheduler) Snapshot() *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := &Snapshot{
+		calleeDict:     make(mapCalleeStore, s.calleeDict.Len()),
+		conflicts:      make(map[string]map[string]struct{}, len(s.conflicts)),
+		conflictChain:  make(map[string][]string, len(s.conflictChain)),
+		conflictCounts: make(map[string]uint64, len(s.conflictCounts)),
+		prefixRules:    append([]prefixRule(nil), s.prefixRules...),
+	}
+	s.calleeDict.ForEach(func(key string, c *Callee) {
+		cp := *c
+		snap.calleeDict[key] = &cp
+	})
+	for a, peers := range s.conflicts {
+		cp := make(map[string]struct{}, len(peers))
+		for b := range peers {
+			cp[b] = struct{}{}
+		}
+		snap.conflicts[a] = cp
+	}
+	for k, chain := range s.conflictChain {
+		snap.conflictChain[k] = append([]string(nil), chain...)
+	}
+	for k, v := range s.conflictCounts {
+		snap.conflictCounts[k] = v
+	}
+	if s.evidence != nil {
+		snap.evidence = make(map[string]arbitrator.Conflict, len(s.evidence))
+		for k, v := range s.evidence {
+			snap.evidence[k] = v
+		}
+	}
+	if s.maintenance != nil {
+		snap.maintenance = make(map[string]struct{}, len(s.maintenance))
+		for k := range s.maintenance {
+			snap.maintenance[k] = struct{}{}
+		}
+	}
+	if s.sequential != nil {
+		snap.sequential = make(map[string]struct{}, len(s.sequential))
+		for k := range s.sequential {
+			snap.sequential[k] = struct{}{}
+		}
+	}
+	return snap
+}
+
+// RestoreFromSnapshot replaces the Scheduler's learned state with an
+// independent copy of snap, undoing any change since Snapshot was taken
+// to the state Snapshot lists. snap itself is left untouched, so it can be restored from more
+// than once.
+func (s *Scheduler) RestoreFromSnapshot(snap *Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calleeDict := make(mapCalleeStore, len(snap.calleeDict))
+	for key, c := range snap.calleeDict {
+		cp := *c
+		calleeDict[key] = &cp
+	}
+	s.calleeDict = calleeDict
+
+	conflicts := make(map[string]map[string]struct{}, len(snap.conflicts))
+	for a, peers := range snap.conflicts {
+		cp := make(map[string]struct{}, len(peers))
+		for b := range peers {
+			cp[b] = struct{}{}
+		}
+		conflicts[a] = cp
+	}
+	s.conflicts = conflicts
+
+	conflictChain := make(map[string][]string, len(snap.conflictChain))
+	for k, chain := range snap.conflictChain {
+		conflictChain[k] = append([]string(nil), chain...)
+	}
+	s.conflictChain = conflictChain
+
+	conflictCounts := make(map[string]uint64, len(snap.conflictCounts))
+	for k, v := range snap.conflictCounts {
+		conflictCounts[k] = v
+	}
+	s.conflictCounts = conflictCounts
+
+	s.prefixRules = append([]prefixRule(nil), snap.prefixRules...)
+
+	s.evidence = nil
+	if snap.evidence != nil {
+		s.evidence = make(map[string]arbitrator.Conflict, len(snap.evidence))
+		for k, v := range snap.evidence {
+			s.evidence[k] = v
+		}
+	}
+
+	s.maintenance = nil
+	if snap.maintenance != nil {
+		s.maintenance = make(map[string]struct{}, len(snap.maintenance))
+		for k := range snap.maintenance {
+			s.maintenance[k] = struct{}{}
+		}
+	}
+
+	s.sequential = nil
+	if snap.sequential != nil {
+		s.sequential = make(map[string]struct{}, len(snap.sequential))
+		for k := range snap.sequential {
+			s.sequential[k] = struct{}{}
+		}
+	}
+}
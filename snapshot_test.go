@@ -0,0 +1,55 @@
+package scheduler
+
+import "testing"
+
+func TestRestoreFromSnapshotUndoesSpeculativeLearning(t *testing.T) {
+	s := NewScheduler()
+	s.Add("0xa:f()", "0xb:g()")
+	before := s.Snapshot()
+
+	// Speculate: learn a brand new conflict and bump a callee's call count.
+	s.Add("0xc:h()", "0xd:i()")
+	s.New([]*Message{{ID: 1, To: "0xa", Sig: "f()"}})
+
+	s.RestoreFromSnapshot(before)
+
+	if s.conflicting(calleeKey("0xc", "h()"), calleeKey("0xd", "i()")) {
+		t.Fatalf("expected speculative conflict to be discarded on restore")
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatalf("expected pre-snapshot conflict to survive restore")
+	}
+	if c, ok := s.calleeDict.Get(calleeKey("0xa", "f()")); ok && c.Calls != 0 {
+		t.Fatalf("expected the speculative call count to be discarded, got %d", c.Calls)
+	}
+}
+
+func TestSnapshotIsIndependentOfLaterMutation(t *testing.T) {
+	s := NewScheduler()
+	s.Add("0xa:f()", "0xb:g()")
+	snap := s.Snapshot()
+
+	s.Add("0xc:h()", "0xd:i()")
+
+	if snap.conflicts["0xc:h()"] != nil {
+		t.Fatalf("expected snapshot's conflict graph to be unaffected by later Add calls")
+	}
+}
+
+func TestRestoreFromSnapshotCanBeAppliedMoreThanOnce(t *testing.T) {
+	s := NewScheduler()
+	s.Add("0xa:f()", "0xb:g()")
+	snap := s.Snapshot()
+
+	s.Add("0xc:h()", "0xd:i()")
+	s.RestoreFromSnapshot(snap)
+	s.Add("0xe:j()", "0xf:k()")
+	s.RestoreFromSnapshot(snap)
+
+	if s.conflicting(calleeKey("0xe", "j()"), calleeKey("0xf", "k()")) {
+		t.Fatalf("expected second restore to discard the second speculative conflict too")
+	}
+	if !s.conflicting(calleeKey("0xa", "f()"), calleeKey("0xb", "g()")) {
+		t.Fatalf("expected the original conflict to still be present after two restores")
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+// StreamBuilder incrementally builds a preview schedule as messages
+// arrive from the mempool one at a time, instead of requiring a
+// caller to collect the whole batch before calling New. Add places each
+// message into the best-fitting generation the moment it arrives, using
+// the same rule packGreedily uses when given a whole batch at once, so a
+// block builder can watch the shape of the schedule grow (and decide,
+// say, that the block is full) without waiting for the batch to close.
+// Seal then reruns the accumulated messages through New's full
+// finalization pass — nonce ordering, AddConstraint enforcement,
+// deferral, maintenance separation, priority ordering, and whichever
+// Strategy the Scheduler is configured with — so the sealed Schedule
+// matches what New would have produced given the same messages as one
+// batch, which can reorder some messages relative to Add's live preview.
+// Building the generation matrix itself is inherently a whole-batch
+// operation (a message's true placement can depend on one that arrives
+// after it), so Seal still needs every message collected internally; what
+// streaming buys is not having to hold the whole batch before starting to
+// place messages, plus the live preview Add returns along the way. A
+// StreamBuilder is not safe for concurrent use by multiple goroutines.
+type StreamBuilder struct {
+	s    *Scheduler
+	msgs []*Message
+	gens [][]*Message
+}
+
+// Stream returns a new StreamBuilder for incrementally scheduling
+// mempool messages as they arrive.
+func (s *Scheduler) Stream() *StreamBuilder {
+	return &StreamBuilder{s: s}
+}
+
+// Add appends m to the stream and returns the index of the preview
+// generation m currently occupies, joining the earliest one it fits
+// without conflict or starting a new one, exactly as packGreedily would.
+func (b *StreamBuilder) Add(m *Message) int {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+	b.s.callee(m.To, m.Sig)
+	b.msgs = append(b.msgs, m)
+	for i, gen := range b.gens {
+		if b.s.fitsGeneration(m, gen) {
+			b.gens[i] = append(gen, m)
+			return i
+		}
+	}
+	b.gens = append(b.gens, []*Message{m})
+	return len(b.gens) - 1
+}
+
+// Len returns how many messages have been added to the stream so far.
+func (b *StreamBuilder) Len() int {
+	return len(b.msgs)
+}
+
+// Preview returns the stream's current generation matrix, in the state
+// Add has incrementally built it. It is not finalized: Seal may reorder
+// or split it further.
+func (b *StreamBuilder) Preview() [][]*Message {
+	return b.gens
+}
+
+// Seal finalizes the stream by running every message added so far
+// through New, and returns the resulting Schedule. The builder can keep
+// accepting messages afterward; a later Seal call reflects everything
+// added up to that point.
+func (b *StreamBuilder) Seal() *Schedule {
+	return b.s.New(b.msgs)
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import "testing"
+
+func TestStreamAddPlacesIndependentMessagesInTheSameGeneration(t *testing.T) {
+	s := NewScheduler()
+	stream := s.Stream()
+
+	first := stream.Add(&Message{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}})
+	second := stream.Add(&Message{ID: 2, To: "0xB", Sig: "g()", ReadSet: []string{"b"}})
+
+	if first != 0 || second != 0 {
+		t.Fatalf("expected both independent messages previewed in generation 0, got %d and %d", first, second)
+	}
+	if stream.Len() != 2 {
+		t.Fatalf("expected 2 messages accumulated, got %d", stream.Len())
+	}
+}
+
+func TestStreamAddSeparatesConflictingMessagesIntoNewGenerations(t *testing.T) {
+	s := NewScheduler()
+	stream := s.Stream()
+
+	first := stream.Add(&Message{ID: 1, To: "0xA", Sig: "f()", WriteSet: []string{"shared"}})
+	second := stream.Add(&Message{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"shared"}})
+
+	if first != 0 || second != 1 {
+		t.Fatalf("expected the conflicting pair previewed in separate generations, got %d and %d", first, second)
+	}
+}
+
+func TestStreamSealMatchesCallingNewWithTheSameBatch(t *testing.T) {
+	s := NewScheduler()
+	stream := s.Stream()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()", ReadSet: []string{"a"}},
+		{ID: 2, To: "0xB", Sig: "g()", WriteSet: []string{"a"}},
+	}
+	for _, m := range msgs {
+		stream.Add(m)
+	}
+
+	sealed := stream.Seal()
+	direct := s.New(msgs)
+	if len(sealed.Generations) != len(direct.Generations) {
+		t.Fatalf("expected Seal to match New's own batch result, got %d vs %d generations", len(sealed.Generations), len(direct.Generations))
+	}
+}
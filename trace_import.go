@@ -0,0 +1,20 @@
+package scheduler
+
+import "github.com/arcology-network/scheduler/tracer"
+
+// MessageFromTransition builds a Message from a tracer.Transition
+// recovered from stock geth prestateTracer/callTracer output, so a
+// Scheduler's conflict history can be bootstrapped from a chain's
+// existing trace infrastructure before it switches execution engines.
+// Since a Transition only carries paths, not the original transaction's
+// gas or nonce, callers should fill those in on the returned Message
+// where they're available.
+func MessageFromTransition(id uint64, to, sig string, t tracer.Transition) *Message {
+	return &Message{
+		ID:       id,
+		To:       to,
+		Sig:      sig,
+		ReadSet:  t.ReadSet,
+		WriteSet: t.WriteSet,
+	}
+}
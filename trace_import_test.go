@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/arcology-network/scheduler/tracer"
+)
+
+func TestMessageFromTransitionCarriesPaths(t *testing.T) {
+	transition := tracer.Transition{
+		TxHash:   "0xhash",
+		ReadSet:  []string{"0xa"},
+		WriteSet: []string{"0xa/storage/0x1"},
+	}
+
+	m := MessageFromTransition(1, "0xa", "f()", transition)
+	if m.ID != 1 || m.To != "0xa" || m.Sig != "f()" {
+		t.Fatalf("unexpected message identity: %+v", m)
+	}
+	if len(m.ReadSet) != 1 || len(m.WriteSet) != 1 {
+		t.Fatalf("expected transition paths to carry over, got %+v", m)
+	}
+}
@@ -0,0 +1,103 @@
+// Package tracer converts go-ethereum's standard prestateTracer and
+// callTracer JSON output into the approximate read/write paths the
+// scheduler package needs, so a chain that still runs stock geth can
+// bootstrap a conflict DB from its existing trace infrastructure before
+// switching execution engines.
+package tracer
+
+import "fmt"
+
+// AccountState mirrors the per-account fields emitted by prestateTracer,
+// restricted to what conflict detection needs.
+type AccountState struct {
+	Balance string
+	Nonce   uint64
+	Storage map[string]string
+}
+
+// PrestateDiff mirrors prestateTracer's "diffMode" output: account state
+// immediately before and after a transaction executed, keyed by address.
+type PrestateDiff struct {
+	Pre  map[string]AccountState
+	Post map[string]AccountState
+}
+
+// Transition is the approximate set of paths one traced transaction
+// touched, expressed the way scheduler.Message expects: "<address>" for
+// the account itself, "<address>/storage/<slot>" for a storage slot.
+type Transition struct {
+	TxHash   string
+	ReadSet  []string
+	WriteSet []string
+}
+
+// FromPrestateDiff derives a Transition from a prestateTracer diff-mode
+// result: every account and slot present in Pre was read, and any
+// account or slot whose value changed between Pre and Post was written.
+// This is approximate — a real execution may read paths that never
+// change and so never appear in Post — but it's sound for scheduling: it
+// never under-reports a write, only over-reports reads.
+func FromPrestateDiff(txHash string, diff PrestateDiff) Transition {
+	t := Transition{TxHash: txHash}
+	for addr, pre := range diff.Pre {
+		t.ReadSet = append(t.ReadSet, addr)
+		for slot := range pre.Storage {
+			t.ReadSet = append(t.ReadSet, storagePath(addr, slot))
+		}
+
+		post, ok := diff.Post[addr]
+		if !ok {
+			continue
+		}
+		if post.Balance != pre.Balance || post.Nonce != pre.Nonce {
+			t.WriteSet = append(t.WriteSet, addr)
+		}
+		for slot, val := range post.Storage {
+			if pre.Storage[slot] != val {
+				t.WriteSet = append(t.WriteSet, storagePath(addr, slot))
+			}
+		}
+	}
+	for addr, post := range diff.Post {
+		if _, ok := diff.Pre[addr]; ok {
+			continue
+		}
+		t.WriteSet = append(t.WriteSet, addr)
+		for slot := range post.Storage {
+			t.WriteSet = append(t.WriteSet, storagePath(addr, slot))
+		}
+	}
+	return t
+}
+
+func storagePath(addr, slot string) string {
+	return fmt.Sprintf("%s/storage/%s", addr, slot)
+}
+
+// Call mirrors the subset of callTracer's call-tree output needed to
+// recover which addresses a transaction reached, for nodes that only
+// have callTracer (not prestateTracer) traces available.
+type Call struct {
+	From  string
+	To    string
+	Calls []Call
+}
+
+// FromCallTrace derives an approximate Transition from a callTracer call
+// tree. Every address reached is treated as both read and written, since
+// a call tree alone can't distinguish the two at the storage-slot level;
+// callers that also have prestateTracer output should prefer
+// FromPrestateDiff.
+func FromCallTrace(txHash string, root Call) Transition {
+	t := Transition{TxHash: txHash}
+	var walk func(c Call)
+	walk = func(c Call) {
+		t.ReadSet = append(t.ReadSet, c.From, c.To)
+		t.WriteSet = append(t.WriteSet, c.To)
+		for _, child := range c.Calls {
+			walk(child)
+		}
+	}
+	walk(root)
+	return t
+}
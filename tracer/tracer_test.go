@@ -0,0 +1,50 @@
+package tracer
+
+import "testing"
+
+func TestFromPrestateDiffFindsChangedBalanceAndSlot(t *testing.T) {
+	diff := PrestateDiff{
+		Pre: map[string]AccountState{
+			"0xa": {Balance: "100", Storage: map[string]string{"0x1": "0"}},
+		},
+		Post: map[string]AccountState{
+			"0xa": {Balance: "90", Storage: map[string]string{"0x1": "1"}},
+		},
+	}
+
+	transition := FromPrestateDiff("0xhash", diff)
+	if len(transition.WriteSet) != 2 {
+		t.Fatalf("expected the account and its changed slot to be writes, got %v", transition.WriteSet)
+	}
+	if len(transition.ReadSet) != 2 {
+		t.Fatalf("expected the account and slot to also be reads, got %v", transition.ReadSet)
+	}
+}
+
+func TestFromPrestateDiffSkipsUnchangedSlot(t *testing.T) {
+	diff := PrestateDiff{
+		Pre: map[string]AccountState{
+			"0xa": {Balance: "100", Storage: map[string]string{"0x1": "0"}},
+		},
+		Post: map[string]AccountState{
+			"0xa": {Balance: "100", Storage: map[string]string{"0x1": "0"}},
+		},
+	}
+
+	transition := FromPrestateDiff("0xhash", diff)
+	if len(transition.WriteSet) != 0 {
+		t.Fatalf("expected no writes for an unchanged account, got %v", transition.WriteSet)
+	}
+}
+
+func TestFromCallTraceCollectsNestedAddresses(t *testing.T) {
+	root := Call{
+		From: "0xa", To: "0xb",
+		Calls: []Call{{From: "0xb", To: "0xc"}},
+	}
+
+	transition := FromCallTrace("0xhash", root)
+	if len(transition.WriteSet) != 2 {
+		t.Fatalf("expected both call targets to be treated as writes, got %v", transition.WriteSet)
+	}
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import "testing"
+
+func TestCrossCheckTransferBalancesConflictsWithBalanceTouchingCall(t *testing.T) {
+	s := NewScheduler()
+	s.SetTouchesBalance("0xc", "withdraw()", true)
+	s.SetCrossCheckTransferBalances(true)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xc"},
+		{ID: 2, To: "0xc", Sig: "withdraw()"},
+	})
+
+	if len(sched.Generations) != 2 {
+		t.Fatalf("expected the transfer and the balance-touching call to be kept apart, got %v", sched.Generations)
+	}
+}
+
+func TestCrossCheckTransferBalancesDisabledByDefault(t *testing.T) {
+	s := NewScheduler()
+	s.SetTouchesBalance("0xc", "withdraw()", true)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xc"},
+		{ID: 2, To: "0xc", Sig: "withdraw()"},
+	})
+
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected no conflict without opting into SetCrossCheckTransferBalances, got %v", sched.Generations)
+	}
+}
+
+func TestCrossCheckTransferBalancesIgnoresUnrelatedRecipient(t *testing.T) {
+	s := NewScheduler()
+	s.SetTouchesBalance("0xc", "withdraw()", true)
+	s.SetCrossCheckTransferBalances(true)
+
+	sched := s.New([]*Message{
+		{ID: 1, To: "0xother"},
+		{ID: 2, To: "0xc", Sig: "withdraw()"},
+	})
+
+	if len(sched.Generations) != 1 || len(sched.Generations[0]) != 2 {
+		t.Fatalf("expected a transfer to an unrelated address not to conflict, got %v", sched.Generations)
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+// TwoPhaseSchedule is the result of PlanWithFallback: an optimistic first
+// pass plus, when the arbitrator aborts part of it, a corrective second
+// pass covering just the aborted messages.
+type TwoPhaseSchedule struct {
+	Phase1  *Schedule
+	Phase2  *Schedule
+	Aborted []*Message
+}
+
+// ArbitrateFunc executes phase1 and reports which message IDs aborted
+// arbitration and need to be rescheduled. Execution is owned by the
+// caller; the scheduler only owns planning.
+type ArbitrateFunc func(phase1 *Schedule) (abortedIDs []uint64)
+
+// PlanWithFallback runs the standard optimistic scheduling pass over msgs,
+// hands it to arbitrate to execute and validate, and — if arbitrate
+// reports aborts — plans a second, corrective schedule for exactly the
+// aborted messages. This is the "schedule wide, run, patch up the losers"
+// pattern integrators otherwise re-implement by hand.
+func (s *Scheduler) PlanWithFallback(msgs []*Message, arbitrate ArbitrateFunc) *TwoPhaseSchedule {
+	phase1 := s.New(msgs)
+	abortedIDs := arbitrate(phase1)
+	if len(abortedIDs) == 0 {
+		return &TwoPhaseSchedule{Phase1: phase1}
+	}
+
+	abortSet := make(map[uint64]struct{}, len(abortedIDs))
+	for _, id := range abortedIDs {
+		abortSet[id] = struct{}{}
+	}
+
+	aborted := make([]*Message, 0, len(abortedIDs))
+	placed := append(append([][]*Message(nil), phase1.Generations...), phase1.SequentialTail)
+	for _, gen := range placed {
+		for _, m := range gen {
+			if _, ok := abortSet[m.ID]; ok {
+				aborted = append(aborted, m)
+			}
+		}
+	}
+
+	return &TwoPhaseSchedule{
+		Phase1:  phase1,
+		Phase2:  s.New(aborted),
+		Aborted: aborted,
+	}
+}
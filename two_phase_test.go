@@ -0,0 +1,37 @@
+package scheduler
+
+import "testing"
+
+func TestPlanWithFallbackNoAborts(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+
+	result := s.PlanWithFallback(msgs, func(*Schedule) []uint64 { return nil })
+
+	if result.Phase1 == nil {
+		t.Fatal("expected a phase1 schedule")
+	}
+	if result.Phase2 != nil {
+		t.Fatal("expected no phase2 schedule when nothing aborts")
+	}
+}
+
+func TestPlanWithFallbackReschedulesAborted(t *testing.T) {
+	s := NewScheduler()
+	msgs := []*Message{
+		{ID: 1, To: "0xA", Sig: "f()"},
+		{ID: 2, To: "0xB", Sig: "g()"},
+	}
+
+	result := s.PlanWithFallback(msgs, func(*Schedule) []uint64 { return []uint64{2} })
+
+	if len(result.Aborted) != 1 || result.Aborted[0].ID != 2 {
+		t.Fatalf("expected message 2 to be aborted, got %v", result.Aborted)
+	}
+	if result.Phase2 == nil || len(result.Phase2.Generations) != 1 {
+		t.Fatalf("expected phase2 to contain a single generation for the retry, got %v", result.Phase2)
+	}
+}
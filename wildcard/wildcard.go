@@ -0,0 +1,219 @@
+// Package wildcard is the single engine this repo uses for matching
+// container "clear all" style paths (e.g. "container/*"). Callers use it
+// in one of two modes against the same compiled Pattern: Expand
+// enumerates every concrete path a pattern matches out of a known set
+// (used to turn a wildcard write into the individual paths it touches),
+// while Covers tests a single concrete path against a pattern directly
+// (used to check a hint like MarkConflictFree's without enumerating
+// anything). Both modes share the same Compile/Match core, so there is
+// exactly one place that understands wildcard syntax.
+package wildcard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// tokenKind distinguishes the pieces a pattern compiles down to.
+type tokenKind int
+
+const (
+	tokLiteral tokenKind = iota
+	// tokSingleSeg matches exactly one path segment — the run of
+	// characters up to the next "/" or the end of the string — and is
+	// produced by a "*" that isn't the final character of the pattern,
+	// e.g. the middle "*" in "/ctrn/*/balance".
+	tokSingleSeg
+	// tokTrailingAny matches everything remaining in the path,
+	// including further "/" separators, and is produced by a "*" that
+	// is the final character of the pattern, e.g. "container/*".
+	tokTrailingAny
+	// tokRange matches exactly one path segment that parses as a plain
+	// base-10 integer n with lo <= n < hi, e.g. the "[0:100]" in
+	// "container/[0:100]" matching "container/0" through "container/99".
+	// It's produced by a "[lo:hi]" segment, half-open like a Go slice.
+	tokRange
+)
+
+type token struct {
+	kind   tokenKind
+	text   string // set only for tokLiteral
+	lo, hi int    // set only for tokRange
+}
+
+// Pattern is a compiled wildcard pattern, ready to be matched against
+// paths without re-parsing the original string on every call.
+type Pattern struct {
+	tokens      []token
+	hasWildcard bool
+}
+
+// Compile parses pattern into a Pattern. A "*" that ends the pattern
+// matches any remaining suffix (including further path segments); a "*"
+// anywhere else matches exactly one path segment. A "[lo:hi]" segment
+// matches exactly one path segment that is a base-10 integer n with
+// lo <= n < hi, half-open like a Go slice — e.g. "container/[0:100]"
+// matches "container/0" through "container/99" but not "container/100",
+// so a transaction that only touches a bounded index range only
+// conflicts with writers inside that range instead of the whole
+// container. A literal "*" can be matched by escaping it as "\*".
+// Compile never fails — any input is a valid pattern, even one with no
+// wildcard tokens at all (or a malformed "[...]" left as a literal), in
+// which case Match degenerates to an exact string comparison.
+func Compile(pattern string) *Pattern {
+	p := &Pattern{}
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			p.tokens = append(p.tokens, token{kind: tokLiteral, text: lit.String()})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern) && pattern[i+1] == '*':
+			lit.WriteByte('*')
+			i++
+		case pattern[i] == '*':
+			flush()
+			if i == len(pattern)-1 {
+				p.tokens = append(p.tokens, token{kind: tokTrailingAny})
+			} else {
+				p.tokens = append(p.tokens, token{kind: tokSingleSeg})
+			}
+			p.hasWildcard = true
+		case pattern[i] == '[':
+			if lo, hi, end, ok := parseRange(pattern[i:]); ok {
+				flush()
+				p.tokens = append(p.tokens, token{kind: tokRange, lo: lo, hi: hi})
+				p.hasWildcard = true
+				i += end
+			} else {
+				lit.WriteByte(pattern[i])
+			}
+		default:
+			lit.WriteByte(pattern[i])
+		}
+	}
+	flush()
+	return p
+}
+
+// parseRange recognizes a "[lo:hi]" range at the start of s, returning
+// the bounds and the index of its closing "]" within s. ok is false for
+// anything that isn't exactly digits, a colon, and digits between
+// brackets, so a malformed or unrelated "[...]" is left as a literal.
+func parseRange(s string) (lo, hi, end int, ok bool) {
+	closeIdx := strings.IndexByte(s, ']')
+	if closeIdx < 0 {
+		return 0, 0, 0, false
+	}
+	body := s[1:closeIdx]
+	colon := strings.IndexByte(body, ':')
+	if colon < 0 {
+		return 0, 0, 0, false
+	}
+	lo, err := strconv.Atoi(body[:colon])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	hi, err = strconv.Atoi(body[colon+1:])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return lo, hi, closeIdx, true
+}
+
+// IsWildcard reports whether p contains at least one unescaped "*" or a
+// "[lo:hi]" range segment.
+func (p *Pattern) IsWildcard() bool {
+	return p.hasWildcard
+}
+
+// Covers is the "test" mode entry point: it reports whether path is
+// matched by p, without enumerating any other paths. It's what a hint
+// registered via MarkConflictFree is checked against, since there the
+// caller only cares about one concrete path at a time and enumerating a
+// whole known set the way Expand does would be wasted work.
+func (p *Pattern) Covers(path string) bool {
+	return p.Match(path)
+}
+
+// SimplePrefix reports whether p is nothing more than a literal prefix
+// followed by a single trailing "*" (e.g. "container/*", or bare "*").
+// Callers that maintain their own prefix-keyed indexes — see the
+// arbitrator's prefixBuckets — can use this to recognize the common case
+// and skip a full per-path Match scan; patterns with a segment wildcard
+// or range, or without a trailing "*", report false.
+func (p *Pattern) SimplePrefix() (prefix string, ok bool) {
+	switch len(p.tokens) {
+	case 0:
+		return "", false
+	case 1:
+		if p.tokens[0].kind == tokTrailingAny {
+			return "", true
+		}
+	case 2:
+		if p.tokens[0].kind == tokLiteral && p.tokens[1].kind == tokTrailingAny {
+			return p.tokens[0].text, true
+		}
+	}
+	return "", false
+}
+
+// Match reports whether path satisfies p.
+func (p *Pattern) Match(path string) bool {
+	pos := 0
+	for _, t := range p.tokens {
+		switch t.kind {
+		case tokLiteral:
+			if !strings.HasPrefix(path[pos:], t.text) {
+				return false
+			}
+			pos += len(t.text)
+		case tokSingleSeg:
+			rest := path[pos:]
+			if end := strings.IndexByte(rest, '/'); end >= 0 {
+				pos += end
+			} else {
+				pos += len(rest)
+			}
+		case tokRange:
+			rest := path[pos:]
+			end := len(rest)
+			if idx := strings.IndexByte(rest, '/'); idx >= 0 {
+				end = idx
+			}
+			seg := rest[:end]
+			n, err := strconv.Atoi(seg)
+			if err != nil || n < t.lo || n >= t.hi {
+				return false
+			}
+			pos += end
+		case tokTrailingAny:
+			return true
+		}
+	}
+	return pos == len(path)
+}
+
+// IsWildcard reports whether pattern uses wildcard syntax: a "*" segment
+// wildcard anywhere, a trailing "*" matching any suffix, or a "[lo:hi]"
+// range segment. An escaped "\*" does not count as a wildcard.
+func IsWildcard(pattern string) bool {
+	return Compile(pattern).IsWildcard()
+}
+
+// Expand returns the concrete paths matched by pattern, chosen from known
+// (typically every path an arbitrator has already seen). If pattern is not
+// a wildcard, it is returned as-is when present in known.
+func Expand(pattern string, known []string) []string {
+	p := Compile(pattern)
+	var matches []string
+	for _, k := range known {
+		if p.Match(k) {
+			matches = append(matches, k)
+		}
+	}
+	return matches
+}
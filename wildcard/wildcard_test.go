@@ -0,0 +1,136 @@
+package wildcard
+
+import "testing"
+
+func TestIsWildcardRecognizesEachPatternClass(t *testing.T) {
+	cases := map[string]bool{
+		"container/foo":     false,
+		"container/*":       true,
+		"/ctrn/*/balance":   true,
+		`container/\*item`:  false,
+		"*":                 true,
+		"container/[0:100]": true,
+	}
+	for pattern, want := range cases {
+		if got := IsWildcard(pattern); got != want {
+			t.Errorf("IsWildcard(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
+
+func TestExpandTrailingWildcardMatchesAnySuffix(t *testing.T) {
+	known := []string{"container/1", "container/2", "other/1", "container"}
+	got := Expand("container/*", known)
+	want := map[string]bool{"container/1": true, "container/2": true}
+	if len(got) != len(want) {
+		t.Fatalf("Expand = %v, want matches for %v", got, want)
+	}
+	for _, p := range got {
+		if !want[p] {
+			t.Fatalf("unexpected match %q", p)
+		}
+	}
+}
+
+func TestExpandSingleSegmentWildcardMatchesExactlyOneSegment(t *testing.T) {
+	known := []string{
+		"/ctrn/foo/balance",
+		"/ctrn/bar/balance",
+		"/ctrn/foo/bar/balance", // two segments between ctrn and balance — should NOT match
+		"/ctrn/foo/other",       // wrong trailing segment — should NOT match
+	}
+	got := Expand("/ctrn/*/balance", known)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %v", got)
+	}
+	for _, p := range got {
+		if p != "/ctrn/foo/balance" && p != "/ctrn/bar/balance" {
+			t.Fatalf("unexpected match %q", p)
+		}
+	}
+}
+
+func TestExpandEscapedLiteralMatchesOnlyTheLiteralAsterisk(t *testing.T) {
+	known := []string{"container/*item", "container/xitem", "container/item"}
+	got := Expand(`container/\*item`, known)
+	if len(got) != 1 || got[0] != "container/*item" {
+		t.Fatalf("expected only the literal asterisk path to match, got %v", got)
+	}
+}
+
+func TestExpandNonWildcardRequiresExactMembership(t *testing.T) {
+	known := []string{"container/1", "container/2"}
+	if got := Expand("container/1", known); len(got) != 1 || got[0] != "container/1" {
+		t.Fatalf("expected exact match, got %v", got)
+	}
+	if got := Expand("container/9", known); len(got) != 0 {
+		t.Fatalf("expected no match for absent path, got %v", got)
+	}
+}
+
+func TestExpandRangeWildcardMatchesOnlyIndicesInsideTheBounds(t *testing.T) {
+	known := []string{
+		"container/0",
+		"container/50",
+		"container/99",
+		"container/100",
+		"container/abc",
+	}
+	got := Expand("container/[0:100]", known)
+	want := map[string]bool{"container/0": true, "container/50": true, "container/99": true}
+	if len(got) != len(want) {
+		t.Fatalf("Expand = %v, want matches for %v", got, want)
+	}
+	for _, p := range got {
+		if !want[p] {
+			t.Fatalf("unexpected match %q", p)
+		}
+	}
+}
+
+func TestMalformedRangeIsTreatedAsALiteral(t *testing.T) {
+	if IsWildcard("container/[abc]") {
+		t.Fatal("expected a malformed range to not be treated as a wildcard")
+	}
+	known := []string{"container/[abc]", "container/x"}
+	got := Expand("container/[abc]", known)
+	if len(got) != 1 || got[0] != "container/[abc]" {
+		t.Fatalf("expected the malformed range to match itself literally, got %v", got)
+	}
+}
+
+func TestCoversTestsASinglePathWithoutEnumerating(t *testing.T) {
+	p := Compile("container/*")
+	if !p.Covers("container/1") {
+		t.Fatal("expected Covers to match a path under the wildcard prefix")
+	}
+	if p.Covers("other/1") {
+		t.Fatal("expected Covers to reject a path outside the wildcard prefix")
+	}
+}
+
+func TestCoversAgreesWithExpandAcrossPatternClasses(t *testing.T) {
+	patterns := []string{"container/*", "/ctrn/*/balance", "container/[0:100]", "container/exact"}
+	paths := []string{"container/1", "/ctrn/foo/balance", "container/50", "container/exact", "container/other"}
+	for _, pattern := range patterns {
+		p := Compile(pattern)
+		for _, path := range paths {
+			want := p.Match(path)
+			if got := p.Covers(path); got != want {
+				t.Errorf("Covers(%q) on pattern %q = %v, want %v", path, pattern, got, want)
+			}
+		}
+	}
+}
+
+func TestCompileSimplePrefixIdentifiesPlainTrailingWildcards(t *testing.T) {
+	if prefix, ok := Compile("container/*").SimplePrefix(); !ok || prefix != "container/" {
+		t.Fatalf("expected SimplePrefix to recognize a plain trailing wildcard, got %q, %v", prefix, ok)
+	}
+	if _, ok := Compile("/ctrn/*/balance").SimplePrefix(); ok {
+		t.Fatal("expected a single-segment wildcard to not be a simple prefix")
+	}
+	if _, ok := Compile("no-wildcard-here").SimplePrefix(); ok {
+		t.Fatal("expected a non-wildcard pattern to not be a simple prefix")
+	}
+}
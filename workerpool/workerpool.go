@@ -0,0 +1,65 @@
+// Package workerpool provides a small persistent worker pool for the
+// parallel sections of arbitration and scheduling. A pool's goroutines
+// are started once and reused across many blocks, instead of every
+// caller spawning and tearing down a fresh batch of goroutines per call,
+// which keeps Go runtime scheduling overhead and CPU usage predictable
+// under sustained load.
+package workerpool
+
+import "sync"
+
+// Pool runs jobs submitted via ForEach on a fixed set of long-lived
+// worker goroutines. The zero value is not usable; construct one with
+// New. Close its workers with Close once the pool is no longer needed.
+type Pool struct {
+	jobs chan func()
+	done chan struct{}
+}
+
+// New starts a Pool with the given number of persistent workers. workers
+// <= 0 is treated as 1.
+func New(workers int) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	p := &Pool{
+		jobs: make(chan func()),
+		done: make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *Pool) worker() {
+	for {
+		select {
+		case job := <-p.jobs:
+			job()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// ForEach runs fn(i) for every i in [0, n), fanning the calls out across
+// the pool's persistent workers and blocking until all of them complete.
+func (p *Pool) ForEach(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		p.jobs <- func() {
+			defer wg.Done()
+			fn(i)
+		}
+	}
+	wg.Wait()
+}
+
+// Close stops the pool's worker goroutines. It must not be called more
+// than once, and ForEach must not be called after Close.
+func (p *Pool) Close() {
+	close(p.done)
+}
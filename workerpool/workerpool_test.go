@@ -0,0 +1,34 @@
+package workerpool
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachRunsEveryIndex(t *testing.T) {
+	p := New(4)
+	defer p.Close()
+
+	var sum int64
+	p.ForEach(100, func(i int) {
+		atomic.AddInt64(&sum, int64(i))
+	})
+	if sum != 4950 {
+		t.Fatalf("expected sum of 0..99 (4950), got %d", sum)
+	}
+}
+
+func TestForEachReusesWorkersAcrossCalls(t *testing.T) {
+	p := New(2)
+	defer p.Close()
+
+	for i := 0; i < 5; i++ {
+		var count int64
+		p.ForEach(10, func(i int) {
+			atomic.AddInt64(&count, 1)
+		})
+		if count != 10 {
+			t.Fatalf("round %d: expected all 10 jobs to run, got %d", i, count)
+		}
+	}
+}